@@ -0,0 +1,125 @@
+package env
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	// The struct tag which defines the precision in bits of a big.Float value, ex: `env-prec:"256"`.
+	TagEnvPrec = "env-prec"
+
+	// The struct tag which defines the rounding mode of a big.Float value, ex: `env-round:"ToZero"`.
+	// The values are the names of the big.RoundingMode constants, case-insensitive.
+	TagEnvRound = "env-round"
+
+	// The precision in bits of big.Float values without a TagEnvPrec struct tag.
+	DefaultFloatPrec uint = 128
+
+	roundingModes = []big.RoundingMode{
+		big.ToNearestEven,
+		big.ToNearestAway,
+		big.ToZero,
+		big.AwayFromZero,
+		big.ToNegativeInf,
+		big.ToPositiveInf,
+	}
+)
+
+// Registers the parsers and formatters for big.Int, big.Float & big.Rat.
+func registerBig() {
+	RegisterParser[big.Int](func(state UnmarshalState) (any, error) {
+		value, exists := state.Read()
+		if !exists {
+			return nil, ErrMissing
+		}
+		base, err := state.Base()
+		if err != nil {
+			return nil, err
+		}
+		parsed, ok := new(big.Int).SetString(value, base)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q in base %d", value, base)
+		}
+		return *parsed, nil
+	})
+	RegisterFormatter[big.Int](func(value any, state UnmarshalState) (string, error) {
+		base, err := state.formatBase()
+		if err != nil {
+			return "", err
+		}
+		integer := value.(big.Int)
+		return integer.Text(base), nil
+	})
+
+	RegisterParser[big.Float](func(state UnmarshalState) (any, error) {
+		value, exists := state.Read()
+		if !exists {
+			return nil, ErrMissing
+		}
+		prec, err := state.Prec()
+		if err != nil {
+			return nil, err
+		}
+		mode, err := state.RoundingMode()
+		if err != nil {
+			return nil, err
+		}
+		parsed, _, err := new(big.Float).SetPrec(prec).SetMode(mode).Parse(value, 0)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q: %w", value, err)
+		}
+		return *parsed, nil
+	})
+	RegisterFormatter[big.Float](func(value any, state UnmarshalState) (string, error) {
+		float := value.(big.Float)
+		return float.Text('g', -1), nil
+	})
+
+	RegisterParser[big.Rat](func(state UnmarshalState) (any, error) {
+		value, exists := state.Read()
+		if !exists {
+			return nil, ErrMissing
+		}
+		parsed, ok := new(big.Rat).SetString(value)
+		if !ok {
+			return nil, fmt.Errorf("invalid rational %q, expected a fraction like 1/3 or a decimal like 0.25", value)
+		}
+		return *parsed, nil
+	})
+	RegisterFormatter[big.Rat](func(value any, state UnmarshalState) (string, error) {
+		rat := value.(big.Rat)
+		return rat.RatString(), nil
+	})
+}
+
+// Returns the precision in bits from the TagEnvPrec struct tag or DefaultFloatPrec.
+func (us UnmarshalState) Prec() (uint, error) {
+	precText, exists := us.Tag(TagEnvPrec, "")
+	if !exists {
+		return DefaultFloatPrec, nil
+	}
+	prec, err := strconv.ParseUint(precText, 10, 32)
+	if err != nil || prec == 0 || prec > big.MaxPrec {
+		return 0, fmt.Errorf("invalid %s %q, expected a number of bits from 1 to %d", TagEnvPrec, precText, uint(big.MaxPrec))
+	}
+	return uint(prec), nil
+}
+
+// Returns the rounding mode from the TagEnvRound struct tag or big.ToNearestEven.
+func (us UnmarshalState) RoundingMode() (big.RoundingMode, error) {
+	roundText, exists := us.Tag(TagEnvRound, "")
+	if !exists {
+		return big.ToNearestEven, nil
+	}
+	names := make([]string, len(roundingModes))
+	for i, mode := range roundingModes {
+		if strings.EqualFold(mode.String(), roundText) {
+			return mode, nil
+		}
+		names[i] = mode.String()
+	}
+	return 0, fmt.Errorf("invalid %s %q, expected one of %s", TagEnvRound, roundText, strings.Join(names, ", "))
+}
@@ -0,0 +1,71 @@
+package env_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/clickermonkey/env"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestBig struct {
+	Supply    *big.Int   `env:"SUPPLY"`
+	Mask      big.Int    `env:"MASK" env-base:"16"`
+	Threshold *big.Float `env:"THRESHOLD" env-prec:"256"`
+	Truncated *big.Float `env:"TRUNCATED" env-prec:"8" env-round:"tozero"`
+	Fee       *big.Rat   `env:"FEE"`
+	Phase     complex128 `env:"PHASE"`
+	Small     complex64  `env:"SMALL" env-default:"0"`
+}
+
+func TestBigNumbers(t *testing.T) {
+	actual, err := env.LoadWith[TestBig](env.Loader{Source: env.MapSource{
+		"SUPPLY":    "21000000000000000000000000",
+		"MASK":      "ffffffffffffffffffff",
+		"THRESHOLD": "0.1000000000000000000000000000001",
+		"TRUNCATED": "1.999",
+		"FEE":       "3/1000",
+		"PHASE":     "1+2i",
+	}})
+	assert.NoError(t, err)
+
+	supply, _ := new(big.Int).SetString("21000000000000000000000000", 10)
+	mask, _ := new(big.Int).SetString("ffffffffffffffffffff", 16)
+	assert.Equal(t, 0, supply.Cmp(actual.Supply))
+	assert.Equal(t, 0, mask.Cmp(&actual.Mask))
+	assert.Equal(t, uint(256), actual.Threshold.Prec())
+	assert.Equal(t, "0.1000000000000000000000000000001", actual.Threshold.Text('g', 31))
+	truncated, _ := actual.Truncated.Float64()
+	assert.Equal(t, 1.9921875, truncated)
+	assert.Equal(t, big.NewRat(3, 1000), actual.Fee)
+	assert.Equal(t, complex(1, 2), actual.Phase)
+
+	vars, err := env.Marshal(actual)
+	assert.NoError(t, err)
+	assert.Equal(t, "21000000000000000000000000", vars["SUPPLY"])
+	assert.Equal(t, "ffffffffffffffffffff", vars["MASK"])
+	assert.Equal(t, "3/1000", vars["FEE"])
+	assert.Equal(t, "(1+2i)", vars["PHASE"])
+	assert.Equal(t, "(0+0i)", vars["SMALL"])
+}
+
+func TestBigNumbersErrors(t *testing.T) {
+	_, err := env.LoadWith[TestBig](env.Loader{Source: env.MapSource{
+		"SUPPLY": "12abc",
+	}})
+	assert.EqualError(t, err, `SUPPLY: error in custom parser for type big.Int: invalid integer "12abc" in base 10`)
+
+	_, err = env.LoadWith[TestBig](env.Loader{Source: env.MapSource{
+		"SUPPLY":    "1",
+		"MASK":      "1",
+		"THRESHOLD": "1",
+		"TRUNCATED": "1",
+		"FEE":       "1/0",
+	}})
+	assert.EqualError(t, err, `FEE: error in custom parser for type big.Rat: invalid rational "1/0", expected a fraction like 1/3 or a decimal like 0.25`)
+
+	_, err = env.LoadWith[struct {
+		Value *big.Float `env:"VALUE" env-round:"up"`
+	}](env.Loader{Source: env.MapSource{"VALUE": "1"}})
+	assert.EqualError(t, err, `VALUE: error in custom parser for type big.Float: invalid env-round "up", expected one of ToNearestEven, ToNearestAway, ToZero, AwayFromZero, ToNegativeInf, ToPositiveInf`)
+}
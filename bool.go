@@ -0,0 +1,83 @@
+package env
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// The struct tag which defines the values which are true, ex: `env-true:"yes,on"`.
+	TagEnvTrue = "env-true"
+
+	// The struct tag which defines the values which are false, ex: `env-false:"no,off"`.
+	TagEnvFalse = "env-false"
+
+	// The struct tag which makes a bool true when its variable is present with
+	// an empty value and false when it's missing, ex: `env-presence:"true"`.
+	TagEnvPresence = "env-presence"
+
+	// The case-insensitive values which are true for bool values without a TagEnvTrue struct tag.
+	TrueValues = []string{"true", "1", "t", "yes", "y", "on", "enabled", "enable"}
+
+	// The case-insensitive values which are false for bool values without a TagEnvFalse struct tag.
+	FalseValues = []string{"false", "0", "f", "no", "n", "off", "disabled", "disable"}
+)
+
+// Returns the values which are true & false based on the TagEnvTrue and
+// TagEnvFalse struct tags, TrueValues, and FalseValues.
+func (us UnmarshalState) BoolValues() (trueValues []string, falseValues []string) {
+	trueValues, falseValues = TrueValues, FalseValues
+	if tag, exists := us.Tag(TagEnvTrue, ""); exists {
+		trueValues = strings.Split(tag, EnvDelimiter)
+	}
+	if tag, exists := us.Tag(TagEnvFalse, ""); exists {
+		falseValues = strings.Split(tag, EnvDelimiter)
+	}
+	return
+}
+
+// Parses a bool, case-insensitive, from the values returned by BoolValues.
+func (us UnmarshalState) ParseBool(text string) (bool, error) {
+	presence, err := us.Presence()
+	if err != nil {
+		return false, fmt.Errorf("parsing %s: %w", TagEnvPresence, err)
+	}
+	if presence && text == "" {
+		return true, nil
+	}
+	trueValues, falseValues := us.BoolValues()
+	trimmed := strings.TrimSpace(text)
+	for _, value := range trueValues {
+		if strings.EqualFold(trimmed, value) {
+			return true, nil
+		}
+	}
+	for _, value := range falseValues {
+		if strings.EqualFold(trimmed, value) {
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("invalid boolean %q, expected one of %s or %s", text, strings.Join(trueValues, ", "), strings.Join(falseValues, ", "))
+}
+
+// Formats a bool with the first value returned by BoolValues.
+func (us UnmarshalState) FormatBool(value bool) string {
+	trueValues, falseValues := us.BoolValues()
+	if value && len(trueValues) > 0 {
+		return trueValues[0]
+	}
+	if !value && len(falseValues) > 0 {
+		return falseValues[0]
+	}
+	return strconv.FormatBool(value)
+}
+
+// Returns whether the TagEnvPresence struct tag makes the presence of the variable mean true.
+func (us UnmarshalState) Presence() (bool, error) {
+	presenceText, exists := us.Tag(TagEnvPresence, "")
+	if !exists {
+		return false, nil
+	}
+	return strconv.ParseBool(presenceText)
+}
@@ -0,0 +1,113 @@
+package env
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// The struct tag which defines the encoding of a []byte or [N]byte value,
+// ex: `env-encoding:"base64"`. Values without the tag are raw text.
+var TagEnvEncoding = "env-encoding"
+
+const (
+	// Bytes are the raw text of the variable.
+	EncodingRaw = "raw"
+	// Bytes are standard base64 encoded, padding is optional.
+	EncodingBase64 = "base64"
+	// Bytes are URL safe base64 encoded, padding is optional.
+	EncodingBase64URL = "base64url"
+	// Bytes are hex encoded.
+	EncodingHex = "hex"
+)
+
+// Returns the encoding in the TagEnvEncoding struct tag or EncodingRaw.
+func (us UnmarshalState) Encoding() (string, error) {
+	encoding, _ := us.Tag(TagEnvEncoding, EncodingRaw)
+	switch encoding {
+	case EncodingRaw, EncodingBase64, EncodingBase64URL, EncodingHex:
+		return encoding, nil
+	}
+	return "", fmt.Errorf("unknown %s %q, expected one of %s, %s, %s, %s", TagEnvEncoding, encoding, EncodingRaw, EncodingBase64, EncodingBase64URL, EncodingHex)
+}
+
+// Decodes the text into bytes with the encoding of this state.
+func (us UnmarshalState) DecodeBytes(text string) ([]byte, error) {
+	encoding, err := us.Encoding()
+	if err != nil {
+		return nil, err
+	}
+	var decoded []byte
+	switch encoding {
+	case EncodingBase64:
+		decoded, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(text, "="))
+	case EncodingBase64URL:
+		decoded, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(text, "="))
+	case EncodingHex:
+		decoded, err = hex.DecodeString(text)
+	default:
+		decoded = []byte(text)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", encoding, err)
+	}
+	return decoded, nil
+}
+
+// Encodes the bytes into text with the encoding of this state.
+func (us UnmarshalState) EncodeBytes(data []byte) (string, error) {
+	encoding, err := us.Encoding()
+	if err != nil {
+		return "", err
+	}
+	switch encoding {
+	case EncodingBase64:
+		return base64.StdEncoding.EncodeToString(data), nil
+	case EncodingBase64URL:
+		return base64.URLEncoding.EncodeToString(data), nil
+	case EncodingHex:
+		return hex.EncodeToString(data), nil
+	}
+	return string(data), nil
+}
+
+// Returns whether the type is a []byte or [N]byte, including named byte types.
+func isBytes(t reflect.Type) bool {
+	return (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t.Elem().Kind() == reflect.Uint8
+}
+
+// Parses a []byte or [N]byte from the environment.
+func parseBytes(rv reflect.Value, state UnmarshalState) error {
+	text, exists := state.Read()
+	if !exists {
+		return ErrMissing
+	}
+	decoded, err := state.DecodeBytes(text)
+	if err != nil {
+		return err
+	}
+	if rv.Kind() == reflect.Slice {
+		if len(decoded) > 0 {
+			rv.SetBytes(decoded)
+		}
+		return nil
+	}
+	if len(decoded) != rv.Len() {
+		return fmt.Errorf("expected %d bytes but got %d", rv.Len(), len(decoded))
+	}
+	for i, b := range decoded {
+		rv.Index(i).SetUint(uint64(b))
+	}
+	return nil
+}
+
+// Returns the bytes of a []byte or [N]byte.
+func bytesOf(rv reflect.Value) []byte {
+	data := make([]byte, rv.Len())
+	for i := range data {
+		data[i] = byte(rv.Index(i).Uint())
+	}
+	return data
+}
@@ -0,0 +1,64 @@
+package env_test
+
+import (
+	"testing"
+
+	"github.com/clickermonkey/env"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestKey [4]byte
+
+type TestBytes struct {
+	Raw       []byte   `env:"RAW"`
+	Base64    []byte   `env:"BASE64" env-encoding:"base64"`
+	Base64URL []byte   `env:"BASE64_URL" env-encoding:"base64url"`
+	Hex       [4]byte  `env:"HEX" env-encoding:"hex"`
+	Key       TestKey  `env:"KEY" env-encoding:"hex" env-required:"false"`
+	Salts     [][]byte `env:"SALTS" env-encoding:"hex" env-required:"false"`
+}
+
+func TestBytesEncodings(t *testing.T) {
+	actual, err := env.LoadWith[TestBytes](env.Loader{Source: env.MapSource{
+		"RAW":        "a,b,c",
+		"BASE64":     "aGVsbG8",
+		"BASE64_URL": "_-8=",
+		"HEX":        "DEADBEEF",
+		"SALTS":      "0102,ff",
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, TestBytes{
+		Raw:       []byte("a,b,c"),
+		Base64:    []byte("hello"),
+		Base64URL: []byte{0xff, 0xef},
+		Hex:       [4]byte{0xde, 0xad, 0xbe, 0xef},
+		Salts:     [][]byte{{1, 2}, {0xff}},
+	}, actual)
+
+	vars, err := env.Marshal(actual)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"RAW":        "a,b,c",
+		"BASE64":     "aGVsbG8=",
+		"BASE64_URL": "_-8=",
+		"HEX":        "deadbeef",
+		"KEY":        "00000000",
+		"SALTS":      "0102,ff",
+	}, vars)
+}
+
+func TestBytesErrors(t *testing.T) {
+	_, err := env.LoadWith[TestBytes](env.Loader{Source: env.MapSource{
+		"RAW":        "",
+		"BASE64":     "",
+		"BASE64_URL": "",
+		"HEX":        "DEADBE",
+	}})
+	assert.EqualError(t, err, "HEX: expected 4 bytes but got 3")
+
+	_, err = env.LoadWith[TestBytes](env.Loader{Source: env.MapSource{
+		"RAW":    "",
+		"BASE64": "not base64!",
+	}})
+	assert.EqualError(t, err, "BASE64: invalid base64: illegal base64 data at input byte 3")
+}
@@ -0,0 +1,107 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// A variable matches more than one differently-cased variable in the source.
+var ErrAmbiguous = errors.New("ambiguous")
+
+// A source which can list the names of its variables, which is needed to
+// look up variables case-insensitively.
+type NamesSource interface {
+	Source
+	Names() []string
+}
+
+var (
+	_ NamesSource = ProcessSource{}
+	_ NamesSource = MapSource{}
+)
+
+func (ProcessSource) Names() []string {
+	environ := os.Environ()
+	names := make([]string, 0, len(environ))
+	for _, pair := range environ {
+		name, _, _ := strings.Cut(pair, "=")
+		names = append(names, name)
+	}
+	return names
+}
+
+func (ms MapSource) Names() []string {
+	names := make([]string, 0, len(ms))
+	for name := range ms {
+		names = append(names, name)
+	}
+	return names
+}
+
+// A variable which matched more than one variable in the source case-insensitively.
+type AmbiguousError struct {
+	Variable string
+	Matches  []string
+}
+
+func (e AmbiguousError) Error() string {
+	return fmt.Sprintf("%s is ambiguous, it matches %s", e.Variable, joinNames(e.Matches, "and"))
+}
+
+func (e AmbiguousError) Unwrap() error {
+	return ErrAmbiguous
+}
+
+// Indexes the names of the source by their lowercase name, sorted.
+func foldNames(source Source) (map[string][]string, error) {
+	namesSource, ok := source.(NamesSource)
+	if !ok {
+		return nil, fmt.Errorf("case-insensitive lookup requires a source with names, %T has none", source)
+	}
+	folded := make(map[string][]string)
+	for _, name := range namesSource.Names() {
+		key := strings.ToLower(name)
+		folded[key] = append(folded[key], name)
+	}
+	for _, names := range folded {
+		slices.Sort(names)
+	}
+	return folded, nil
+}
+
+// Returns the names in the source which match the name case-insensitively,
+// only those with a value under the TagEnvTrim & TagEnvEmpty struct tags when
+// withValue is true. An exact match is the only match.
+func (us UnmarshalState) foldedMatches(name string, withValue bool) []string {
+	if us.loader == nil || us.loader.folded == nil {
+		return nil
+	}
+	matches := us.loader.folded[strings.ToLower(name)]
+	if withValue {
+		var valued []string
+		for _, match := range matches {
+			if _, exists := us.applyEmpty(us.Source().LookupEnv(match)); exists {
+				valued = append(valued, match)
+			}
+		}
+		matches = valued
+	}
+	if slices.Contains(matches, name) {
+		return []string{name}
+	}
+	return matches
+}
+
+// Returns an AmbiguousError when a variable of this state has no exact match
+// and more than one case-insensitive match with a value.
+func (us UnmarshalState) checkAmbiguous() error {
+	for _, varName := range append(slices.Clip(us.Variables), us.Deprecated...) {
+		if matches := us.foldedMatches(varName, true); len(matches) > 1 {
+			return AmbiguousError{Variable: varName, Matches: matches}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package env_test
+
+import (
+	"testing"
+
+	"github.com/clickermonkey/env"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestCaseInsensitive struct {
+	Path string `env:"PATH"`
+	Home string `env:"HOME"`
+	Temp string `env:"TEMP" env-required:"false"`
+}
+
+func TestCaseInsensitiveLookup(t *testing.T) {
+	actual, err := env.LoadWith[TestCaseInsensitive](env.Loader{CaseInsensitive: true, Source: env.MapSource{
+		"Path": "/bin",
+		"HOME": "/root",
+		"home": "/ignored",
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, TestCaseInsensitive{Path: "/bin", Home: "/root"}, actual)
+
+	_, err = env.LoadWith[TestCaseInsensitive](env.Loader{Source: env.MapSource{
+		"Path": "/bin",
+		"HOME": "/root",
+	}})
+	assert.ErrorIs(t, err, env.ErrRequired)
+}
+
+func TestCaseInsensitiveAmbiguous(t *testing.T) {
+	_, err := env.LoadWith[TestCaseInsensitive](env.Loader{CaseInsensitive: true, Source: env.MapSource{
+		"Path": "/bin",
+		"path": "/usr/bin",
+		"HOME": "/root",
+	}})
+	assert.ErrorIs(t, err, env.ErrAmbiguous)
+	assert.EqualError(t, err, "PATH: PATH is ambiguous, it matches Path and path")
+
+	var ambiguous env.AmbiguousError
+	assert.ErrorAs(t, err, &ambiguous)
+	assert.Equal(t, []string{"Path", "path"}, ambiguous.Matches)
+}
+
+func TestCaseInsensitiveEmpty(t *testing.T) {
+	type Config struct {
+		Path string `env:"PATH" env-empty:"unset"`
+	}
+	actual, err := env.LoadWith[Config](env.Loader{CaseInsensitive: true, Source: env.MapSource{
+		"Path": "",
+		"path": "/bin",
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, Config{Path: "/bin"}, actual)
+
+	_, err = env.LoadWith[Config](env.Loader{CaseInsensitive: true, Source: env.MapSource{
+		"Path": " ",
+		"path": "/bin",
+	}})
+	assert.ErrorIs(t, err, env.ErrAmbiguous)
+}
@@ -0,0 +1,98 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+var (
+	// Called when a deprecated environment variable is read. By default a
+	// warning is logged with slog, set to nil to ignore deprecations.
+	OnDeprecated = func(deprecation Deprecation) {
+		slog.Warn("deprecated environment variable", "variable", deprecation.Variable, "replacement", deprecation.Replacement, "sunset", deprecation.Sunset)
+	}
+
+	// When true a deprecated environment variable read after its sunset
+	// date is an error instead of a warning.
+	StrictDeprecations = false
+
+	// A deprecated environment variable was read after its sunset date.
+	ErrDeprecated = errors.New("deprecated")
+)
+
+// A deprecated environment variable that was read in place of its replacement.
+type Deprecation struct {
+	Variable    string
+	Replacement string
+	// The zero time when there is no sunset date.
+	Sunset time.Time
+}
+
+func (d Deprecation) Error() string {
+	if d.Sunset.IsZero() {
+		return fmt.Sprintf("%s is deprecated, use %s", d.Variable, d.Replacement)
+	}
+	return fmt.Sprintf("%s is deprecated since %s, use %s", d.Variable, d.Sunset.Format(time.DateOnly), d.Replacement)
+}
+
+func (d Deprecation) Unwrap() error {
+	return ErrDeprecated
+}
+
+// Returns the partial deprecated environment variable names specified in the TagEnvDeprecated struct tag.
+func (us UnmarshalState) DeprecatedEnvs() []string {
+	deprecated, exists := us.Tag(TagEnvDeprecated, "")
+	if !exists || deprecated == "" {
+		return nil
+	}
+	return strings.Split(deprecated, EnvDelimiter)
+}
+
+// Returns the sunset date specified in the TagEnvSunset struct tag, if any.
+func (us UnmarshalState) Sunset() (time.Time, error) {
+	sunset, exists := us.Tag(TagEnvSunset, "")
+	if !exists || sunset == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.DateOnly, sunset)
+}
+
+// Reports a deprecated variable if one is what this state will read. When
+// StrictDeprecations is on and the sunset date has passed it's returned as an error.
+func (us UnmarshalState) checkDeprecated() error {
+	if len(us.Deprecated) == 0 {
+		return nil
+	}
+	for _, varName := range us.Variables {
+		if _, exists := us.lookupValue(varName); exists {
+			return nil
+		}
+	}
+	for _, varName := range us.Deprecated {
+		if _, exists := us.lookupValue(varName); !exists {
+			continue
+		}
+		sunset, err := us.Sunset()
+		if err != nil {
+			return fmt.Errorf("parsing %s of %s: %w", TagEnvSunset, us, err)
+		}
+		deprecation := Deprecation{
+			Variable: varName,
+			Sunset:   sunset,
+		}
+		if len(us.Variables) > 0 {
+			deprecation.Replacement = us.Variables[0]
+		}
+		if StrictDeprecations && !sunset.IsZero() && time.Now().After(sunset) {
+			return deprecation
+		}
+		if OnDeprecated != nil {
+			OnDeprecated(deprecation)
+		}
+		return nil
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	// The struct tag which defines what an empty variable means, EmptyValue or
+	// EmptyUnset, ex: `env-empty:"unset"`.
+	TagEnvEmpty = "env-empty"
+
+	// The struct tag which trims whitespace around a variable, ex: `env-trim:"true"`.
+	TagEnvTrim = "env-trim"
+
+	// The struct tag which defines the value that sets a pointer, slice, or map
+	// to nil, ex: `env-null:"null"`.
+	TagEnvNull = "env-null"
+
+	// What an empty variable means for fields without a TagEnvEmpty struct tag.
+	// Fields with TagEnvPresence always treat an empty variable as set.
+	DefaultEmpty = EmptyValue
+
+	// Whether whitespace is trimmed for fields without a TagEnvTrim struct tag.
+	DefaultTrim = false
+
+	// The value which sets a pointer, slice, or map to nil for fields without a
+	// TagEnvNull struct tag. An empty string is no null value.
+	DefaultNull = ""
+)
+
+const (
+	// An empty variable is a value, which overrides defaults.
+	EmptyValue = "value"
+	// An empty variable is treated as unset, so the next variable or default is used.
+	EmptyUnset = "unset"
+)
+
+// Returns what an empty variable means from the TagEnvEmpty struct tag or DefaultEmpty.
+func (us UnmarshalState) Empty() (string, error) {
+	empty, _ := us.Tag(TagEnvEmpty, DefaultEmpty)
+	switch empty {
+	case EmptyValue, EmptyUnset:
+		return empty, nil
+	}
+	return "", fmt.Errorf("unknown value %q, expected %s or %s", empty, EmptyValue, EmptyUnset)
+}
+
+// Returns whether whitespace is trimmed from the TagEnvTrim struct tag or DefaultTrim.
+func (us UnmarshalState) Trim() (bool, error) {
+	trimText, exists := us.Tag(TagEnvTrim, "")
+	if !exists {
+		return DefaultTrim, nil
+	}
+	return strconv.ParseBool(trimText)
+}
+
+// Returns the value which sets a nilable value to nil from the TagEnvNull
+// struct tag or DefaultNull, and whether there is one.
+func (us UnmarshalState) Null() (string, bool) {
+	null, _ := us.Tag(TagEnvNull, DefaultNull)
+	return null, null != ""
+}
+
+// Returns whether the value of this state is the null value.
+func (us *UnmarshalState) IsNull() bool {
+	null, hasNull := us.Null()
+	if !hasNull {
+		return false
+	}
+	value, exists := us.Read()
+	return exists && value == null
+}
+
+// Reads the value of the first variable which is set, ignoring defaults,
+// trimming, and TagEnvEmpty. A variable which is set but empty returns "", true.
+func (us UnmarshalState) ReadEnv() (value string, set bool) {
+	for _, varName := range append(slices.Clip(us.Variables), us.Deprecated...) {
+		if value, set = us.lookup(varName); set {
+			return
+		}
+	}
+	return "", false
+}
+
+// Looks up a variable, trimming it and treating it as unset when empty based
+// on the TagEnvTrim & TagEnvEmpty struct tags. Invalid tags are reported by checkEmpty.
+func (us UnmarshalState) lookupValue(name string) (string, bool) {
+	if us.loader != nil && us.loader.folded != nil {
+		matches := us.foldedMatches(name, true)
+		if len(matches) != 1 {
+			return "", false
+		}
+		name = matches[0]
+	}
+	return us.applyEmpty(us.Source().LookupEnv(name))
+}
+
+// Trims the value and treats it as unset when empty based on the TagEnvTrim &
+// TagEnvEmpty struct tags. Presence flags are set by an empty value, so they
+// ignore DefaultEmpty.
+func (us UnmarshalState) applyEmpty(value string, exists bool) (string, bool) {
+	if !exists {
+		return "", false
+	}
+	if trim, _ := us.Trim(); trim {
+		value = strings.TrimSpace(value)
+	}
+	if presence, _ := us.Presence(); presence {
+		return value, true
+	}
+	if empty, _ := us.Empty(); empty == EmptyUnset && value == "" {
+		return "", false
+	}
+	return value, true
+}
+
+// Returns an error when the TagEnvEmpty or TagEnvTrim struct tags are invalid.
+func (us UnmarshalState) checkEmpty() error {
+	empty, err := us.Empty()
+	if err != nil {
+		return fmt.Errorf("parsing %s of %s: %w", TagEnvEmpty, us, err)
+	}
+	_, emptyTagged := us.Tag(TagEnvEmpty, "")
+	if presence, _ := us.Presence(); presence && emptyTagged && empty == EmptyUnset {
+		return fmt.Errorf("%s %q of %s cannot be used with %s", TagEnvEmpty, empty, us, TagEnvPresence)
+	}
+	if _, err := us.Trim(); err != nil {
+		return fmt.Errorf("parsing %s of %s: %w", TagEnvTrim, us, err)
+	}
+	return nil
+}
+
+// Returns whether the value can be nil.
+func isNilable(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package env_test
+
+import (
+	"testing"
+
+	"github.com/clickermonkey/env"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestEmptyValue struct {
+	Value string
+	Set   bool
+	Empty bool
+}
+
+func (v *TestEmptyValue) UnmarshalEnv(state env.UnmarshalState) error {
+	v.Value, v.Set = state.ReadEnv()
+	v.Empty = v.Set && v.Value == ""
+	return nil
+}
+
+type TestEmpty struct {
+	Port     int               `env:"PORT" env-empty:"unset" env-default:"8080"`
+	Host     string            `env:"HOST,HOSTNAME" env-empty:"unset"`
+	Name     string            `env:"NAME" env-default:"name"`
+	Trimmed  string            `env:"TRIMMED" env-trim:"true"`
+	Timeout  *int              `env:"TIMEOUT" env-null:"null" env-default:"30"`
+	Tags     []string          `env:"TAGS" env-null:"null"`
+	Labels   map[string]string `env:"LABELS" env-null:"null" env-required:"false"`
+	Password TestEmptyValue    `env:"PASSWORD"`
+	Token    TestEmptyValue    `env:"TOKEN"`
+}
+
+func TestEmptySemantics(t *testing.T) {
+	actual, err := env.LoadWith[TestEmpty](env.Loader{Source: env.MapSource{
+		"PORT":     "",
+		"HOST":     "",
+		"HOSTNAME": "localhost",
+		"NAME":     "",
+		"TRIMMED":  "  value \n",
+		"TIMEOUT":  "null",
+		"TAGS":     "null",
+		"PASSWORD": "",
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, TestEmpty{
+		Port:     8080,
+		Host:     "localhost",
+		Name:     "",
+		Trimmed:  "value",
+		Password: TestEmptyValue{Set: true, Empty: true},
+	}, actual)
+
+	timeout := 30
+	actual, err = env.LoadWith[TestEmpty](env.Loader{Source: env.MapSource{
+		"HOSTNAME": "h",
+		"TRIMMED":  "   ",
+		"TAGS":     "a",
+		"TOKEN":    "t",
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, TestEmpty{
+		Port:    8080,
+		Host:    "h",
+		Name:    "name",
+		Timeout: &timeout,
+		Tags:    []string{"a"},
+		Token:   TestEmptyValue{Value: "t", Set: true},
+	}, actual)
+}
+
+func TestEmptyUnsetRequired(t *testing.T) {
+	_, err := env.LoadWith[TestEmpty](env.Loader{Source: env.MapSource{
+		"HOST":    "",
+		"TRIMMED": "x",
+		"TAGS":    "a",
+	}})
+	assert.EqualError(t, err, "HOST,HOSTNAME: required")
+}
+
+func TestEmptyNullMarshal(t *testing.T) {
+	vars, err := env.Marshal(TestEmpty{Port: 1, Host: "h", Name: "n", Trimmed: "t"})
+	assert.NoError(t, err)
+	assert.Equal(t, "null", vars["TIMEOUT"])
+	assert.Equal(t, "null", vars["TAGS"])
+	assert.Equal(t, "null", vars["LABELS"])
+}
+
+func TestEmptyUnsetPresence(t *testing.T) {
+	type Config struct {
+		Flag bool `env:"FLAG" env-presence:"true"`
+	}
+	env.DefaultEmpty = env.EmptyUnset
+	defer func() { env.DefaultEmpty = env.EmptyValue }()
+
+	actual, err := env.LoadWith[Config](env.Loader{Source: env.MapSource{"FLAG": ""}})
+	assert.NoError(t, err)
+	assert.True(t, actual.Flag)
+
+	type Invalid struct {
+		Flag bool `env:"FLAG" env-presence:"true" env-empty:"unset"`
+	}
+	_, err = env.LoadWith[Invalid](env.Loader{Source: env.MapSource{"FLAG": ""}})
+	assert.EqualError(t, err, `env-empty "unset" of FLAG cannot be used with env-presence`)
+}
@@ -0,0 +1,73 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+var (
+	enumNames map[reflect.Type][]string
+
+	// The struct tag which defines the allowed values, case-insensitive, ex: `env-enum:"debug,info,warn,error"`.
+	TagEnvEnum = "env-enum"
+)
+
+// Registers a parser and formatter for the given type which maps the names,
+// case-insensitive, to values. When a value has multiple names the first
+// name in sorted order is used when formatting.
+func RegisterEnum[T comparable](values map[string]T) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	key := reflect.TypeFor[T]()
+	enumNames[key] = names
+
+	RegisterParser[T](func(state UnmarshalState) (any, error) {
+		text, exists := state.Read()
+		if !exists {
+			return nil, ErrMissing
+		}
+		name, err := matchEnum(text, names)
+		if err != nil {
+			return nil, err
+		}
+		return values[name], nil
+	})
+	RegisterFormatter[T](func(value any, state UnmarshalState) (string, error) {
+		for _, name := range names {
+			if values[name] == value.(T) {
+				return name, nil
+			}
+		}
+		return "", fmt.Errorf("%v is not one of %s", value, strings.Join(names, ", "))
+	})
+}
+
+// Returns the names of the enum registered for the given type, in sorted order.
+func EnumNames(t reflect.Type) []string {
+	return enumNames[t]
+}
+
+// Returns the allowed values in the TagEnvEnum struct tag, if any.
+func (us UnmarshalState) Enum() []string {
+	enum, exists := us.Tag(TagEnvEnum, "")
+	if !exists {
+		return nil
+	}
+	return strings.Split(enum, EnvDelimiter)
+}
+
+// Returns the name which matches the text case-insensitively.
+func matchEnum(text string, names []string) (string, error) {
+	for _, name := range names {
+		if strings.EqualFold(text, name) {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("invalid value %q, expected one of %s", text, strings.Join(names, ", "))
+}
@@ -4,7 +4,6 @@ import (
 	"encoding"
 	"errors"
 	"fmt"
-	"os"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -18,8 +17,9 @@ type Unmarshaller interface {
 	UnmarshalEnv(state UnmarshalState) error
 }
 
-// A validator that's ran after successful parsing. This cannot be used in
-// conjuction with env.Unmarshaller or encoding.TextUnmarshaler.
+// A validator that's ran after successful parsing, however the value was
+// decoded (env.Unmarshaller, encoding.TextUnmarshaler, a Parser, or by kind).
+// Pointers are validated through their element.
 type Validator interface {
 	ValidateEnv(state UnmarshalState) error
 }
@@ -28,10 +28,11 @@ type Validator interface {
 type Parser func(state UnmarshalState) (any, error)
 
 var (
-	cacheLock sync.Mutex
-	cache     map[reflect.Type]any
-	parsers   map[reflect.Type]Parser
-	kindBits  map[reflect.Kind]int
+	cacheLock  sync.Mutex
+	cache      map[reflect.Type]any
+	parsers    map[reflect.Type]Parser
+	formatters map[reflect.Type]Formatter
+	kindBits   map[reflect.Kind]int
 
 	// The struct tag which can store the environment variable name(s)
 	// Skip can be used to skip a field. When multiple properties are defined,
@@ -47,6 +48,26 @@ var (
 	// The struct tag which defines a custom required option.
 	TagEnvRequired = "env-required"
 
+	// The struct tag which places a field in a named group with an optional
+	// GroupRule, ex: `env-group:"auth,exactly-one"`. Grouped fields are optional
+	// unless TagEnvRequired says otherwise.
+	TagEnvGroup = "env-group"
+
+	// The struct tag which defines deprecated environment variable name(s) that
+	// are read when none of the names in TagEnv exist, ex: `env:"PASSWORD" env-deprecated:"PASS"`.
+	TagEnvDeprecated = "env-deprecated"
+
+	// The struct tag which defines the date (YYYY-MM-DD) after which the
+	// TagEnvDeprecated names are errors when StrictDeprecations is true.
+	TagEnvSunset = "env-sunset"
+
+	// The struct tag which defines the base of an integer value. A base of 0
+	// detects the base from its prefix (0x, 0o, 0b) and allows underscores, ex: 1_000.
+	TagEnvBase = "env-base"
+
+	// The base of integer values without a TagEnvBase struct tag.
+	DefaultIntBase = 10
+
 	// The delimiter for multiple environment variable names in the TagEnv struct tag.
 	EnvDelimiter = ","
 
@@ -69,26 +90,46 @@ var (
 func init() {
 	cache = make(map[reflect.Type]any)
 	parsers = make(map[reflect.Type]Parser)
+	formatters = make(map[reflect.Type]Formatter)
+	enumNames = make(map[reflect.Type][]string)
+	formats = make(map[string]format)
+	impls = make(map[reflect.Type]map[string]reflect.Type)
 	kindBits = map[reflect.Kind]int{
-		reflect.Int8:    8,
-		reflect.Int16:   16,
-		reflect.Int32:   32,
-		reflect.Int64:   64,
-		reflect.Int:     64,
-		reflect.Uint8:   8,
-		reflect.Uint16:  16,
-		reflect.Uint32:  32,
-		reflect.Uint64:  64,
-		reflect.Uint:    64,
-		reflect.Float32: 32,
-		reflect.Float64: 64,
+		reflect.Int8:       8,
+		reflect.Int16:      16,
+		reflect.Int32:      32,
+		reflect.Int64:      64,
+		reflect.Int:        64,
+		reflect.Uint8:      8,
+		reflect.Uint16:     16,
+		reflect.Uint32:     32,
+		reflect.Uint64:     64,
+		reflect.Uint:       64,
+		reflect.Float32:    32,
+		reflect.Float64:    64,
+		reflect.Complex64:  64,
+		reflect.Complex128: 128,
 	}
 
 	// native parsers
 	RegisterParser[time.Duration](func(state UnmarshalState) (any, error) {
-		value, _ := state.Read()
+		value, exists := state.Read()
+		if !exists {
+			return nil, ErrMissing
+		}
 		return time.ParseDuration(value)
 	})
+
+	// native formatters
+	RegisterFormatter[time.Duration](func(value any, state UnmarshalState) (string, error) {
+		return value.(time.Duration).String(), nil
+	})
+
+	registerBuiltins()
+	registerTime()
+	registerBig()
+	registerFormats()
+	registerOptional()
 }
 
 // Registers a custom parser for the given type.
@@ -151,40 +192,51 @@ func MustLoad[T any]() T {
 
 // Loads the value (expected to be pointer) from environment variables.
 func Parse(value any) error {
-	var err error
-	defer func() {
-		if recovered := recover(); recovered != nil {
-			if r, ok := recovered.(error); ok {
-				err = r
-			} else {
-				err = fmt.Errorf("%v", recovered)
-			}
-		}
-	}()
+	return Loader{}.Parse(value)
+}
 
-	rv := reflect.ValueOf(value)
-	parseError := parse(rv, UnmarshalState{})
-	if parseError != nil && !errors.Is(parseError, ErrMissing) {
-		err = parseError
+// Parses the value from the environment and validates it.
+func parse(rv reflect.Value, state UnmarshalState) error {
+	if err := decode(rv, state); err != nil {
+		return err
 	}
-
-	return err
+	return validate(rv, state)
 }
 
-func parse(rv reflect.Value, state UnmarshalState) error {
-	if unmarshaller, ok := rv.Interface().(Unmarshaller); ok {
-		return unmarshaller.UnmarshalEnv(state)
+// Decodes the value from the environment.
+func decode(rv reflect.Value, state UnmarshalState) error {
+	// A formatted value is decoded as a whole.
+	if name, hasFormat := state.Format(); hasFormat {
+		return parseFormat(rv, state, name)
 	}
 
-	if unmarshaller, ok := rv.Interface().(encoding.TextUnmarshaler); ok {
-		parsed, exists := state.Read()
-		if !exists {
-			return ErrMissing
+	// The null value sets pointers, slices, and maps to nil.
+	if isNilable(rv) && state.IsNull() {
+		rv.SetZero()
+		return nil
+	}
+
+	// Pointers are parsed through their element unless the pointer type has a parser.
+	if _, hasParser := parserFor(rv.Type()); rv.Kind() == reflect.Pointer && !hasParser {
+		if rv.IsNil() {
+			new := reflect.New(rv.Type().Elem())
+			newState := state
+			newState.allocated = true
+			err := parse(new.Elem(), newState)
+			if err != nil {
+				return err
+			}
+			rv.Set(new)
+			return nil
 		}
-		return unmarshaller.UnmarshalText([]byte(parsed))
+		return parse(rv.Elem(), state)
 	}
 
-	if parser, ok := parsers[rv.Type()]; ok {
+	if unmarshaller, ok := asInterface[Unmarshaller](rv); ok {
+		return unmarshaller.UnmarshalEnv(state)
+	}
+
+	if parser, ok := parserFor(rv.Type()); ok {
 		parsed, err := parser(state)
 		if err != nil {
 			return fmt.Errorf("error in custom parser for type %v: %w", rv.Type(), err)
@@ -193,25 +245,28 @@ func parse(rv reflect.Value, state UnmarshalState) error {
 		return nil
 	}
 
+	if unmarshaller, ok := asInterface[encoding.TextUnmarshaler](rv); ok {
+		parsed, exists := state.Read()
+		if !exists {
+			return ErrMissing
+		}
+		return unmarshaller.UnmarshalText([]byte(parsed))
+	}
+
 	// Complex types
 	switch rv.Kind() {
-	case reflect.Pointer:
-		if rv.IsNil() {
-			new := reflect.New(rv.Type().Elem())
-			err := parse(new.Elem(), state)
-			if err != nil {
+	case reflect.Array:
+		if isBytes(rv.Type()) {
+			if err := parseBytes(rv, state); err != nil {
 				return err
 			}
-			rv.Set(new)
-		} else {
-			return parse(rv.Elem(), state)
+			break
 		}
-	case reflect.Array:
 		text, exists := state.Read()
 		if !exists {
 			return ErrMissing
 		}
-		split, err := state.Split(text, rv.Len())
+		split, err := state.SplitElements(text, rv.Len())
 		if err != nil {
 			return fmt.Errorf("error splitting: %w", err)
 		}
@@ -219,15 +274,22 @@ func parse(rv reflect.Value, state UnmarshalState) error {
 			return fmt.Errorf("cannot parse array from env, expected %d elements but got %d for %s", rv.Len(), len(split), state)
 		}
 		for i, s := range split {
-			splitState := state
-			splitState.read = &s
-			splitState.readExists = true
-			err := parse(rv.Index(i), splitState)
+			splitState, err := state.element(s, rv.Type().Elem())
+			if err != nil {
+				return fmt.Errorf("at index %d: %w", i, err)
+			}
+			err = parse(rv.Index(i), splitState)
 			if err != nil {
 				return fmt.Errorf("at index %d: %w", i, err)
 			}
 		}
 	case reflect.Slice:
+		if isBytes(rv.Type()) {
+			if err := parseBytes(rv, state); err != nil {
+				return err
+			}
+			break
+		}
 		text, exists := state.Read()
 		if !exists {
 			return ErrMissing
@@ -235,24 +297,31 @@ func parse(rv reflect.Value, state UnmarshalState) error {
 		if text == "" {
 			return nil
 		}
-		split, err := state.Split(text, -1)
+		split, err := state.SplitElements(text, -1)
 		if err != nil {
 			return fmt.Errorf("error splitting: %w", err)
 		}
 		rv.Set(reflect.MakeSlice(rv.Type(), len(split), len(split)))
 		for i, s := range split {
-			splitState := state
-			splitState.read = &s
-			splitState.readExists = true
-			err := parse(rv.Index(i), splitState)
+			splitState, err := state.element(s, rv.Type().Elem())
+			if err != nil {
+				return fmt.Errorf("at index %d: %w", i, err)
+			}
+			err = parse(rv.Index(i), splitState)
 			if err != nil {
 				return fmt.Errorf("at index %d: %w", i, err)
 			}
 		}
+	case reflect.Map:
+		if err := parseMap(rv, state); err != nil {
+			return err
+		}
 	case reflect.Struct:
 		valid := 0
 		missing := 0
 		var firstError error
+		var groups fieldGroups
+		defaulted := setDefaults(rv, state)
 
 		for i := range rv.NumField() {
 			fieldStruct := rv.Type().Field(i)
@@ -261,14 +330,46 @@ func parse(rv reflect.Value, state UnmarshalState) error {
 			if skip {
 				continue
 			}
+			fieldState.promoteHooks(rv.Type(), fieldStruct)
+
+			group, rule, groupErr := fieldState.Group()
+			if groupErr != nil {
+				return fmt.Errorf("parsing %s of %s: %w", TagEnvGroup, fieldState, groupErr)
+			}
+
+			if err := fieldState.checkDeprecated(); err != nil {
+				return err
+			}
+			if err := fieldState.checkAmbiguous(); err != nil {
+				return FieldError{Variables: fieldState.Variables, Err: err}
+			}
+			if err := fieldState.checkEmpty(); err != nil {
+				return err
+			}
 
-			err := parse(field.Addr(), fieldState)
+			err := parse(field, fieldState)
+
+			if group != "" {
+				found := ""
+				if err == nil {
+					found, _ = fieldState.found(field.Type())
+				}
+				groupErr = groups.add(group, rule, fieldState.valueVariables(field.Type()), found)
+				if groupErr != nil {
+					return groupErr
+				}
+			}
+
+			// A field given a computed default is valid without a variable.
+			if defaulted && errors.Is(err, ErrMissing) && !field.IsZero() {
+				err = nil
+			}
 
 			if err != nil {
 				isMissing := errors.Is(err, ErrMissing)
 				isRequired := errors.Is(err, ErrRequired)
 				if isMissing || isRequired {
-					required, requiredErr := fieldState.Required(field.Kind() != reflect.Pointer)
+					required, requiredErr := fieldState.Required(field.Kind() != reflect.Pointer && group == "" && !hasInterface[optionalValue](field.Type()))
 					if requiredErr != nil {
 						return fmt.Errorf("parsing %s of %s: %w", TagEnvRequired, fieldState, requiredErr)
 					}
@@ -276,12 +377,20 @@ func parse(rv reflect.Value, state UnmarshalState) error {
 						if isRequired {
 							firstError = err
 						} else {
-							firstError = fmt.Errorf("%s: %w", fieldState, ErrRequired)
+							// An interface is missing when its TypeVariable is, which is named instead.
+							variables := fieldState.Variables
+							var missingErr FieldError
+							if field.Kind() == reflect.Interface && errors.As(err, &missingErr) {
+								variables = missingErr.Variables
+							}
+							firstError = FieldError{Variables: variables, Err: ErrRequired}
 						}
 					}
 					missing++
+				} else if _, named := err.(FieldError); named && field.Kind() == reflect.Interface {
+					return err
 				} else {
-					return fmt.Errorf("%s: %w", fieldState, err)
+					return FieldError{Variables: fieldState.Variables, Err: err}
 				}
 			} else {
 				valid++
@@ -290,25 +399,58 @@ func parse(rv reflect.Value, state UnmarshalState) error {
 		if firstError != nil {
 			return firstError
 		}
-		if valid == 0 && missing > 0 {
+		if err := groups.check(); err != nil {
+			return err
+		}
+		// A struct allocated for a nil pointer is discarded when it's missing.
+		isMissing := valid == 0 && missing > 0
+		if !isMissing || !state.allocated {
+			if err := afterLoad(rv, state); err != nil {
+				return err
+			}
+		}
+		if isMissing {
 			return ErrMissing
 		}
 
-	case reflect.Chan, reflect.Complex128, reflect.Complex64, reflect.Func, reflect.Interface, reflect.Map, reflect.Invalid, reflect.Uintptr, reflect.UnsafePointer:
+	case reflect.Interface:
+		if err := parseImpl(rv, state); err != nil {
+			return err
+		}
+	case reflect.Chan, reflect.Func, reflect.Invalid, reflect.Uintptr, reflect.UnsafePointer:
 		return fmt.Errorf("kind %s not supported", rv.Kind())
 	default:
 		// For simple types, text should be an actual value.
 		text, exists := state.Read()
 		if !exists {
+			if rv.Kind() == reflect.Bool {
+				presence, err := state.Presence()
+				if err != nil {
+					return fmt.Errorf("parsing %s: %w", TagEnvPresence, err)
+				}
+				if presence {
+					rv.SetBool(false)
+					return nil
+				}
+			}
 			return ErrMissing
 		}
 
+		// Allowed values are matched case-insensitively and replaced with their spelling in the tag.
+		if enum := state.Enum(); enum != nil {
+			matched, err := matchEnum(text, enum)
+			if err != nil {
+				return err
+			}
+			text = matched
+		}
+
 		// Simple types
 		switch rv.Kind() {
 		case reflect.String:
 			rv.SetString(text)
 		case reflect.Bool:
-			parsed, err := strconv.ParseBool(text)
+			parsed, err := state.ParseBool(text)
 			if err != nil {
 				return err
 			}
@@ -319,14 +461,20 @@ func parse(rv reflect.Value, state UnmarshalState) error {
 				return err
 			}
 			rv.SetFloat(parsed)
+		case reflect.Complex64, reflect.Complex128:
+			parsed, err := strconv.ParseComplex(text, kindBits[rv.Kind()])
+			if err != nil {
+				return err
+			}
+			rv.SetComplex(parsed)
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			parsed, err := strconv.ParseInt(text, 10, kindBits[rv.Kind()])
+			parsed, err := state.ParseInt(text, kindBits[rv.Kind()])
 			if err != nil {
 				return err
 			}
 			rv.SetInt(parsed)
 		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			parsed, err := strconv.ParseUint(text, 10, kindBits[rv.Kind()])
+			parsed, err := state.ParseUint(text, kindBits[rv.Kind()])
 			if err != nil {
 				return err
 			}
@@ -334,10 +482,17 @@ func parse(rv reflect.Value, state UnmarshalState) error {
 		}
 	}
 
-	if validator, ok := rv.Interface().(Validator); ok {
+	return nil
+}
+
+// Validates the decoded value. Pointers without a parser were validated through their element.
+func validate(rv reflect.Value, state UnmarshalState) error {
+	if _, hasParser := parserFor(rv.Type()); rv.Kind() == reflect.Pointer && (!hasParser || rv.IsNil()) {
+		return nil
+	}
+	if validator, ok := asInterface[Validator](rv); ok {
 		return validator.ValidateEnv(state)
 	}
-
 	return nil
 }
 
@@ -345,44 +500,66 @@ func parse(rv reflect.Value, state UnmarshalState) error {
 type UnmarshalState struct {
 	Field     *reflect.StructField
 	Variables []string
+	// Deprecated variable names which are read when none of the Variables exist.
+	Deprecated []string
 
+	loader     *Loader
 	read       *string
 	readExists bool
+	// The nesting level of slice/array/map elements, which selects the delimiter.
+	level int
+	// Whether the value was allocated for a nil pointer, which is left nil when missing.
+	allocated bool
+	// Whether the hooks of an embedded struct are called by the struct containing it.
+	promotedDefaults  bool
+	promotedAfterLoad bool
 }
 
 // Creates a new UnmarshalState for the given struct field and parent state
 func newFieldState(field reflect.StructField, parent UnmarshalState) (fieldState UnmarshalState, skip bool) {
 	fieldState = UnmarshalState{
-		Field: &field,
+		Field:  &field,
+		loader: parent.loader,
 	}
 
-	defaultVariable := field.Name
-	if field.Anonymous {
-		defaultVariable = ""
-	}
-	envs := fieldState.Envs(defaultVariable)
+	envs := fieldState.Envs(fieldState.defaultName(field))
 	if envs == nil {
 		skip = true
 		return
 	}
 
-	if len(parent.Variables) == 0 {
-		fieldState.Variables = envs
-	} else {
-		for _, stateVar := range parent.Variables {
-			for _, fieldVar := range envs {
-				if strings.HasPrefix(fieldVar, AbsoluteName) {
-					fieldState.Variables = append(fieldState.Variables, strings.TrimPrefix(fieldVar, AbsoluteName))
-				} else {
-					fieldState.Variables = append(fieldState.Variables, stateVar+fieldVar)
-				}
-			}
-		}
+	fieldState.Variables = joinVariables(parent.Variables, envs)
+
+	deprecated := fieldState.DeprecatedEnvs()
+	if len(deprecated) > 0 {
+		fieldState.Deprecated = joinVariables(parent.Variables, deprecated)
+	}
+	if len(parent.Deprecated) > 0 {
+		fieldState.Deprecated = append(fieldState.Deprecated, joinVariables(parent.Deprecated, append(envs, deprecated...))...)
 	}
 
 	return
 }
 
+// Joins the parent variables with the field variables. Field variables which
+// start with AbsoluteName are not prefixed by the parent variables.
+func joinVariables(parentVariables []string, fieldVariables []string) []string {
+	if len(parentVariables) == 0 {
+		return fieldVariables
+	}
+	joined := make([]string, 0, len(parentVariables)*len(fieldVariables))
+	for _, stateVar := range parentVariables {
+		for _, fieldVar := range fieldVariables {
+			if strings.HasPrefix(fieldVar, AbsoluteName) {
+				joined = append(joined, strings.TrimPrefix(fieldVar, AbsoluteName))
+			} else {
+				joined = append(joined, stateVar+fieldVar)
+			}
+		}
+	}
+	return joined
+}
+
 // Reads the environment value defined by the variables in this state.
 // Returns the whether the value or a default exists at all.
 func (us *UnmarshalState) Read() (value string, exists bool) {
@@ -390,11 +567,19 @@ func (us *UnmarshalState) Read() (value string, exists bool) {
 		return *us.read, us.readExists
 	}
 	for _, varName := range us.Variables {
-		value, exists = os.LookupEnv(varName)
+		value, exists = us.lookupValue(varName)
 		if exists {
 			break
 		}
 	}
+	if !exists {
+		for _, varName := range us.Deprecated {
+			value, exists = us.lookupValue(varName)
+			if exists {
+				break
+			}
+		}
+	}
 	if !exists {
 		value, exists = us.Default("")
 	}
@@ -403,6 +588,26 @@ func (us *UnmarshalState) Read() (value string, exists bool) {
 	return
 }
 
+// Looks up a single environment variable.
+func (us UnmarshalState) lookup(name string) (string, bool) {
+	if us.loader != nil && us.loader.folded != nil {
+		matches := us.foldedMatches(name, false)
+		if len(matches) != 1 {
+			return "", false
+		}
+		name = matches[0]
+	}
+	return us.Source().LookupEnv(name)
+}
+
+// Returns the source of environment variables for this state.
+func (us UnmarshalState) Source() Source {
+	if us.loader == nil || us.loader.Source == nil {
+		return ProcessSource{}
+	}
+	return us.loader.Source
+}
+
 // Returns the environment variable names for this state, EnvDelimiter delimited.
 func (us UnmarshalState) String() string {
 	return strings.Join(us.Variables, EnvDelimiter)
@@ -430,8 +635,14 @@ func (us UnmarshalState) Tag(key string, missing string) (string, bool) {
 	return value, true
 }
 
-// Returns the default value specified on the struct tag if any exists.
+// Returns the default value specified on the struct tag if any exists. The
+// active profile's default (ex: env-default-prod) is preferred over TagEnvDefault.
 func (us UnmarshalState) Default(otherwise string) (string, bool) {
+	if profile := us.Profile(); profile != "" {
+		if value, exists := us.Tag(TagEnvDefault+"-"+profile, otherwise); exists {
+			return value, true
+		}
+	}
 	return us.Tag(TagEnvDefault, otherwise)
 }
 
@@ -449,6 +660,75 @@ func (us UnmarshalState) Required(appearsRequired bool) (bool, error) {
 	return strconv.ParseBool(requiredText)
 }
 
+// Parses a signed integer with the given bit size, based on the TagEnvUnit
+// and TagEnvBase struct tags.
+func (us UnmarshalState) ParseInt(text string, bitSize int) (int64, error) {
+	unit, err := us.Unit()
+	if err != nil {
+		return 0, err
+	}
+	if unit == UnitBytes {
+		parsed, err := ParseBytes(text)
+		if errors.Is(err, strconv.ErrRange) || (err == nil && parsed > uint64(1)<<(bitSize-1)-1) {
+			return 0, overflowError(text, bitSize, "signed")
+		}
+		return int64(parsed), err
+	}
+	base, err := us.Base()
+	if err != nil {
+		return 0, err
+	}
+	parsed, err := strconv.ParseInt(text, base, bitSize)
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, overflowError(text, bitSize, "signed")
+	}
+	return parsed, err
+}
+
+// Parses an unsigned integer with the given bit size, based on the TagEnvUnit
+// and TagEnvBase struct tags.
+func (us UnmarshalState) ParseUint(text string, bitSize int) (uint64, error) {
+	unit, err := us.Unit()
+	if err != nil {
+		return 0, err
+	}
+	if unit == UnitBytes {
+		parsed, err := ParseBytes(text)
+		if errors.Is(err, strconv.ErrRange) || (err == nil && bitSize < 64 && parsed > uint64(1)<<bitSize-1) {
+			return 0, overflowError(text, bitSize, "unsigned")
+		}
+		return parsed, err
+	}
+	base, err := us.Base()
+	if err != nil {
+		return 0, err
+	}
+	parsed, err := strconv.ParseUint(text, base, bitSize)
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, overflowError(text, bitSize, "unsigned")
+	}
+	return parsed, err
+}
+
+// Returns the base for integers in the TagEnvBase struct tag or DefaultIntBase.
+// A base of 0 detects the base from the prefix (0x, 0o, 0b) and allows underscores.
+func (us UnmarshalState) Base() (int, error) {
+	baseText, exists := us.Tag(TagEnvBase, "")
+	if !exists {
+		return DefaultIntBase, nil
+	}
+	base, err := strconv.Atoi(baseText)
+	if err != nil || base == 1 || base < 0 || base > 36 {
+		return 0, fmt.Errorf("invalid %s %q, expected 0 or 2 to 36", TagEnvBase, baseText)
+	}
+	return base, nil
+}
+
+// Returns an error for an integer which doesn't fit in the bit size.
+func overflowError(text string, bitSize int, signedness string) error {
+	return fmt.Errorf("%q overflows %d-bit %s integer: %w", text, bitSize, signedness, strconv.ErrRange)
+}
+
 // Returns a regular expression to split array/split values based on
 // the env.TagEnvDelim struct tag and env.DefaultDelimiter.
 func (us UnmarshalState) Delim() (*regexp.Regexp, error) {
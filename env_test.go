@@ -4,6 +4,7 @@ import (
 	"encoding"
 	"errors"
 	"os"
+	"reflect"
 	"testing"
 	"time"
 
@@ -127,6 +128,116 @@ type TestExplode struct {
 	Conn TestExplodeInner `env:"DB_,DATABASE_"`
 }
 
+type TestGroupOAuth struct {
+	ClientID string `env:"CLIENT_ID"`
+	Secret   string `env:"SECRET"`
+}
+
+type TestGroup struct {
+	APIKey string          `env:"TG_API_KEY" env-group:"auth,exactly-one"`
+	OAuth  *TestGroupOAuth `env:"TG_OAUTH_" env-group:"auth"`
+}
+
+type TestGroupBasic struct {
+	User     string `env:"USER"`
+	Password string `env:"PASSWORD" env-required:"false"`
+}
+
+type TestGroupStruct struct {
+	Token string         `env:"TGS_TOKEN" env-group:"auth"`
+	Basic TestGroupBasic `env:"TGS_BASIC_" env-group:"auth"`
+}
+
+type TestGroupAtLeastOne struct {
+	Email string `env:"TGALO_EMAIL" env-group:"contact,at-least-one"`
+	Phone string `env:"TGALO_PHONE" env-group:"contact"`
+}
+
+type TestGroupDefault struct {
+	A string `env:"TGD_A" env-group:"g,exactly-one" env-default:"x"`
+	B string `env:"TGD_B" env-group:"g"`
+}
+
+type TestDeprecated struct {
+	Pass string `env:"TD_PASSWORD" env-deprecated:"TD_PASS"`
+	User string `env:"TD_USERNAME" env-deprecated:"TD_USER" env-sunset:"2000-01-01"`
+}
+
+type TestDeprecatedEmpty struct {
+	Name string `env:"TDE_NEW" env-deprecated:"TDE_OLD" env-empty:"unset" env-sunset:"2000-01-01"`
+}
+
+type TestDeprecatedPrefix struct {
+	Conn TestExplodeInner `env:"TDP_DB_" env-deprecated:"TDP_DATABASE_"`
+}
+
+func loadDeprecated[T any](strict bool) (T, []env.Deprecation, error) {
+	var deprecations []env.Deprecation
+	onDeprecated := env.OnDeprecated
+	env.OnDeprecated = func(d env.Deprecation) {
+		deprecations = append(deprecations, d)
+	}
+	env.StrictDeprecations = strict
+	defer func() {
+		env.OnDeprecated = onDeprecated
+		env.StrictDeprecations = false
+	}()
+	loaded, err := env.Load[T]()
+	return loaded, deprecations, err
+}
+
+type TestProfile struct {
+	Host string `env:"TP_HOST" env-default:"db" env-default-dev:"localhost"`
+	Port int    `env:"TP_PORT" env-default:"0" env-default-prod:"5432"`
+}
+
+type TestProfileDefaults struct {
+	defaults map[string]string
+}
+
+func (tpd *TestProfileDefaults) UnmarshalEnv(state env.UnmarshalState) error {
+	tpd.defaults = state.Defaults()
+	return nil
+}
+
+type TestBase struct {
+	Mask  uint32      `env:"TB_MASK" env-base:"0"`
+	Limit int64       `env:"TB_LIMIT" env-base:"0"`
+	Hex   []uint16    `env:"TB_HEX" env-base:"16"`
+	Mode  os.FileMode `env:"TB_MODE"`
+	Small int8        `env:"TB_SMALL" env-default:"0"`
+}
+
+type TestBool struct {
+	Feature bool  `env:"TBO_FEATURE"`
+	Custom  bool  `env:"TBO_CUSTOM" env-true:"si" env-false:"no" env-default:"no"`
+	Flag    bool  `env:"TBO_FLAG" env-presence:"true"`
+	Maybe   *bool `env:"TBO_MAYBE"`
+}
+
+type TestMode int
+
+const (
+	TestModeOff TestMode = iota
+	TestModeRead
+	TestModeWrite
+)
+
+func init() {
+	env.RegisterEnum(map[string]TestMode{
+		"off":   TestModeOff,
+		"read":  TestModeRead,
+		"write": TestModeWrite,
+	})
+}
+
+type TestEnum struct {
+	Mode   TestMode   `env:"TEN_MODE"`
+	Modes  []TestMode `env:"TEN_MODES" env-required:"false"`
+	Level  string     `env:"TEN_LEVEL" env-enum:"debug,info,warn,error" env-default:"info"`
+	Weight int        `env:"TEN_WEIGHT" env-enum:"1,2,4" env-default:"1"`
+}
+
 func TestCases(t *testing.T) {
 	cases := []struct {
 		name          string
@@ -494,6 +605,466 @@ func TestCases(t *testing.T) {
 			},
 			expectedError: "DB_PASS,DB_PASSWORD,DATABASE_PASS,DATABASE_PASSWORD: required",
 		},
+		{
+			name: "TestGroup first",
+			set: map[string]string{
+				"TG_API_KEY": "key",
+			},
+			get: func() (any, error) {
+				return env.Load[TestGroup]()
+			},
+			check: func(t *testing.T, value any) {
+				actual := value.(TestGroup)
+				assert.Equal(t, "key", actual.APIKey)
+				assert.Nil(t, actual.OAuth)
+			},
+		},
+		{
+			name: "TestGroup second",
+			set: map[string]string{
+				"TG_OAUTH_CLIENT_ID": "id",
+				"TG_OAUTH_SECRET":    "secret",
+			},
+			get: func() (any, error) {
+				return env.Load[TestGroup]()
+			},
+			check: func(t *testing.T, value any) {
+				actual := value.(TestGroup)
+				assert.Equal(t, "", actual.APIKey)
+				assert.Equal(t, &TestGroupOAuth{ClientID: "id", Secret: "secret"}, actual.OAuth)
+			},
+		},
+		{
+			name: "TestGroup exclusive error",
+			set: map[string]string{
+				"TG_API_KEY":         "key",
+				"TG_OAUTH_CLIENT_ID": "id",
+				"TG_OAUTH_SECRET":    "secret",
+			},
+			get: func() (any, error) {
+				return env.Load[TestGroup]()
+			},
+			expectedError: "TG_API_KEY and TG_OAUTH_CLIENT_ID are mutually exclusive",
+		},
+		{
+			name: "TestGroup required error",
+			set:  map[string]string{},
+			get: func() (any, error) {
+				return env.Load[TestGroup]()
+			},
+			expectedError: "TG_API_KEY, TG_OAUTH_CLIENT_ID or TG_OAUTH_SECRET is required",
+		},
+		{
+			name: "TestGroupStruct member",
+			set: map[string]string{
+				"TGS_BASIC_USER":     "user",
+				"TGS_BASIC_PASSWORD": "pass",
+			},
+			get: func() (any, error) {
+				return env.Load[TestGroupStruct]()
+			},
+			check: func(t *testing.T, value any) {
+				actual := value.(TestGroupStruct)
+				assert.Equal(t, TestGroupStruct{Basic: TestGroupBasic{User: "user", Password: "pass"}}, actual)
+			},
+		},
+		{
+			name: "TestGroupStruct exclusive error",
+			set: map[string]string{
+				"TGS_TOKEN":          "token",
+				"TGS_BASIC_PASSWORD": "pass",
+				"TGS_BASIC_USER":     "user",
+			},
+			get: func() (any, error) {
+				return env.Load[TestGroupStruct]()
+			},
+			expectedError: "TGS_TOKEN and TGS_BASIC_USER are mutually exclusive",
+		},
+		{
+			name: "TestGroupAtLeastOne both",
+			set: map[string]string{
+				"TGALO_EMAIL": "a@b.c",
+				"TGALO_PHONE": "555",
+			},
+			get: func() (any, error) {
+				return env.Load[TestGroupAtLeastOne]()
+			},
+			check: func(t *testing.T, value any) {
+				actual := value.(TestGroupAtLeastOne)
+				assert.Equal(t, "a@b.c", actual.Email)
+				assert.Equal(t, "555", actual.Phone)
+			},
+		},
+		{
+			name: "TestGroupAtLeastOne error",
+			set:  map[string]string{},
+			get: func() (any, error) {
+				return env.Load[TestGroupAtLeastOne]()
+			},
+			expectedError: "TGALO_EMAIL or TGALO_PHONE is required",
+		},
+		{
+			name: "TestGroupDefault default not present",
+			set: map[string]string{
+				"TGD_B": "b",
+			},
+			get: func() (any, error) {
+				return env.Load[TestGroupDefault]()
+			},
+			check: func(t *testing.T, value any) {
+				actual := value.(TestGroupDefault)
+				assert.Equal(t, "x", actual.A)
+				assert.Equal(t, "b", actual.B)
+			},
+		},
+		{
+			name: "TestGroupDefault default alone",
+			set:  map[string]string{},
+			get: func() (any, error) {
+				return env.Load[TestGroupDefault]()
+			},
+			expectedError: "TGD_A or TGD_B is required",
+		},
+		{
+			name: "TestGroupDefault exclusive error",
+			set: map[string]string{
+				"TGD_A": "a",
+				"TGD_B": "b",
+			},
+			get: func() (any, error) {
+				return env.Load[TestGroupDefault]()
+			},
+			expectedError: "TGD_A and TGD_B are mutually exclusive",
+		},
+		{
+			name: "TestProfile none",
+			set:  map[string]string{},
+			get: func() (any, error) {
+				return env.Load[TestProfile]()
+			},
+			check: func(t *testing.T, value any) {
+				actual := value.(TestProfile)
+				assert.Equal(t, "db", actual.Host)
+				assert.Equal(t, 0, actual.Port)
+			},
+		},
+		{
+			name: "TestProfile variable",
+			set: map[string]string{
+				"APP_PROFILE": "DEV",
+			},
+			get: func() (any, error) {
+				return env.Load[TestProfile]()
+			},
+			check: func(t *testing.T, value any) {
+				actual := value.(TestProfile)
+				assert.Equal(t, "localhost", actual.Host)
+				assert.Equal(t, 0, actual.Port)
+			},
+		},
+		{
+			name: "TestProfile loader",
+			set: map[string]string{
+				"APP_PROFILE": "dev",
+				"TP_HOST":     "remote",
+			},
+			get: func() (any, error) {
+				return env.LoadWith[TestProfile](env.Loader{Profile: "prod"})
+			},
+			check: func(t *testing.T, value any) {
+				actual := value.(TestProfile)
+				assert.Equal(t, "remote", actual.Host)
+				assert.Equal(t, 5432, actual.Port)
+			},
+		},
+		{
+			name: "TestProfile defaults",
+			set:  map[string]string{},
+			get: func() (any, error) {
+				return env.Load[struct {
+					Defaults TestProfileDefaults `env:"TPD" env-default:"a" env-default-dev:"b" env-default-prod:""`
+				}]()
+			},
+			check: func(t *testing.T, value any) {
+				actual := value.(struct {
+					Defaults TestProfileDefaults `env:"TPD" env-default:"a" env-default-dev:"b" env-default-prod:""`
+				})
+				assert.Equal(t, map[string]string{"": "a", "dev": "b", "prod": ""}, actual.Defaults.defaults)
+			},
+		},
+		{
+			name: "TestBase success",
+			set: map[string]string{
+				"TB_MASK":  "0x1F",
+				"TB_LIMIT": "-1_000_000",
+				"TB_HEX":   "ff,0A",
+				"TB_MODE":  "0755",
+			},
+			get: func() (any, error) {
+				return env.Load[TestBase]()
+			},
+			check: func(t *testing.T, value any) {
+				actual := value.(TestBase)
+				assert.Equal(t, uint32(0x1f), actual.Mask)
+				assert.Equal(t, int64(-1_000_000), actual.Limit)
+				assert.Equal(t, []uint16{0xff, 0x0a}, actual.Hex)
+				assert.Equal(t, os.FileMode(0755), actual.Mode)
+			},
+		},
+		{
+			name: "TestBase prefixes",
+			set: map[string]string{
+				"TB_MASK":  "0b1010",
+				"TB_LIMIT": "0o755",
+				"TB_HEX":   "",
+				"TB_MODE":  "0o600",
+			},
+			get: func() (any, error) {
+				return env.Load[TestBase]()
+			},
+			check: func(t *testing.T, value any) {
+				actual := value.(TestBase)
+				assert.Equal(t, uint32(10), actual.Mask)
+				assert.Equal(t, int64(0755), actual.Limit)
+				assert.Equal(t, os.FileMode(0600), actual.Mode)
+			},
+		},
+		{
+			name: "TestBase overflow",
+			set: map[string]string{
+				"TB_MASK":  "0x1F",
+				"TB_LIMIT": "0",
+				"TB_HEX":   "",
+				"TB_MODE":  "644",
+				"TB_SMALL": "128",
+			},
+			get: func() (any, error) {
+				return env.Load[TestBase]()
+			},
+			expectedError: `TB_SMALL: "128" overflows 8-bit signed integer: value out of range`,
+		},
+		{
+			name: "TestBase file mode",
+			set: map[string]string{
+				"TB_MASK":  "0x1F",
+				"TB_LIMIT": "0",
+				"TB_HEX":   "",
+				"TB_MODE":  "rwx",
+			},
+			get: func() (any, error) {
+				return env.Load[TestBase]()
+			},
+			expectedError: `TB_MODE: error in custom parser for type fs.FileMode: invalid file mode "rwx", expected an octal number like 0755`,
+		},
+		{
+			name: "TestBool vocabulary",
+			set: map[string]string{
+				"TBO_FEATURE": "Yes",
+				"TBO_CUSTOM":  "SI",
+				"TBO_FLAG":    "",
+				"TBO_MAYBE":   "off",
+			},
+			get: func() (any, error) {
+				return env.Load[TestBool]()
+			},
+			check: func(t *testing.T, value any) {
+				f := false
+				actual := value.(TestBool)
+				assert.Equal(t, true, actual.Feature)
+				assert.Equal(t, true, actual.Custom)
+				assert.Equal(t, true, actual.Flag)
+				assert.Equal(t, &f, actual.Maybe)
+			},
+		},
+		{
+			name: "TestBool presence missing",
+			set: map[string]string{
+				"TBO_FEATURE": "disabled",
+			},
+			get: func() (any, error) {
+				return env.Load[TestBool]()
+			},
+			check: func(t *testing.T, value any) {
+				actual := value.(TestBool)
+				assert.Equal(t, false, actual.Feature)
+				assert.Equal(t, false, actual.Custom)
+				assert.Equal(t, false, actual.Flag)
+				assert.Nil(t, actual.Maybe)
+			},
+		},
+		{
+			name: "TestBool presence value",
+			set: map[string]string{
+				"TBO_FEATURE": "on",
+				"TBO_FLAG":    "false",
+			},
+			get: func() (any, error) {
+				return env.Load[TestBool]()
+			},
+			check: func(t *testing.T, value any) {
+				actual := value.(TestBool)
+				assert.Equal(t, false, actual.Flag)
+			},
+		},
+		{
+			name: "TestBool error",
+			set: map[string]string{
+				"TBO_FEATURE": "on",
+				"TBO_CUSTOM":  "yes",
+			},
+			get: func() (any, error) {
+				return env.Load[TestBool]()
+			},
+			expectedError: `TBO_CUSTOM: invalid boolean "yes", expected one of si or no`,
+		},
+		{
+			name: "TestEnum success",
+			set: map[string]string{
+				"TEN_MODE":   "Write",
+				"TEN_MODES":  "read,OFF",
+				"TEN_LEVEL":  "WARN",
+				"TEN_WEIGHT": "4",
+			},
+			get: func() (any, error) {
+				return env.Load[TestEnum]()
+			},
+			check: func(t *testing.T, value any) {
+				actual := value.(TestEnum)
+				assert.Equal(t, TestModeWrite, actual.Mode)
+				assert.Equal(t, []TestMode{TestModeRead, TestModeOff}, actual.Modes)
+				assert.Equal(t, "warn", actual.Level)
+				assert.Equal(t, 4, actual.Weight)
+			},
+		},
+		{
+			name: "TestEnum registered error",
+			set: map[string]string{
+				"TEN_MODE": "execute",
+			},
+			get: func() (any, error) {
+				return env.Load[TestEnum]()
+			},
+			expectedError: `TEN_MODE: error in custom parser for type env_test.TestMode: invalid value "execute", expected one of off, read, write`,
+		},
+		{
+			name: "TestEnum tag error",
+			set: map[string]string{
+				"TEN_MODE":  "off",
+				"TEN_LEVEL": "trace",
+			},
+			get: func() (any, error) {
+				return env.Load[TestEnum]()
+			},
+			expectedError: `TEN_LEVEL: invalid value "trace", expected one of debug, info, warn, error`,
+		},
+		{
+			name: "TestEnum names",
+			set:  map[string]string{},
+			get: func() (any, error) {
+				return env.EnumNames(reflect.TypeFor[TestMode]()), nil
+			},
+			check: func(t *testing.T, value any) {
+				assert.Equal(t, []string{"off", "read", "write"}, value)
+			},
+		},
+		{
+			name: "TestDeprecated current",
+			set: map[string]string{
+				"TD_PASSWORD": "p",
+				"TD_USERNAME": "u",
+			},
+			get: func() (any, error) {
+				loaded, deprecations, err := loadDeprecated[TestDeprecated](true)
+				assert.Empty(t, deprecations)
+				return loaded, err
+			},
+			check: func(t *testing.T, value any) {
+				actual := value.(TestDeprecated)
+				assert.Equal(t, "p", actual.Pass)
+				assert.Equal(t, "u", actual.User)
+			},
+		},
+		{
+			name: "TestDeprecated warnings",
+			set: map[string]string{
+				"TD_PASS": "p",
+				"TD_USER": "u",
+			},
+			get: func() (any, error) {
+				loaded, deprecations, err := loadDeprecated[TestDeprecated](false)
+				assert.Equal(t, []env.Deprecation{
+					{Variable: "TD_PASS", Replacement: "TD_PASSWORD"},
+					{Variable: "TD_USER", Replacement: "TD_USERNAME", Sunset: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)},
+				}, deprecations)
+				return loaded, err
+			},
+			check: func(t *testing.T, value any) {
+				actual := value.(TestDeprecated)
+				assert.Equal(t, "p", actual.Pass)
+				assert.Equal(t, "u", actual.User)
+			},
+		},
+		{
+			name: "TestDeprecatedEmpty empty replacement",
+			set: map[string]string{
+				"TDE_NEW": "",
+				"TDE_OLD": "o",
+			},
+			get: func() (any, error) {
+				loaded, deprecations, err := loadDeprecated[TestDeprecatedEmpty](false)
+				assert.Equal(t, []env.Deprecation{
+					{Variable: "TDE_OLD", Replacement: "TDE_NEW", Sunset: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)},
+				}, deprecations)
+				return loaded, err
+			},
+			check: func(t *testing.T, value any) {
+				assert.Equal(t, "o", value.(TestDeprecatedEmpty).Name)
+			},
+		},
+		{
+			name: "TestDeprecatedEmpty strict",
+			set: map[string]string{
+				"TDE_NEW": "",
+				"TDE_OLD": "o",
+			},
+			get: func() (any, error) {
+				loaded, _, err := loadDeprecated[TestDeprecatedEmpty](true)
+				assert.ErrorIs(t, err, env.ErrDeprecated)
+				return loaded, err
+			},
+			expectedError: "TDE_OLD is deprecated since 2000-01-01, use TDE_NEW",
+		},
+		{
+			name: "TestDeprecated strict",
+			set: map[string]string{
+				"TD_PASS": "p",
+				"TD_USER": "u",
+			},
+			get: func() (any, error) {
+				loaded, _, err := loadDeprecated[TestDeprecated](true)
+				assert.ErrorIs(t, err, env.ErrDeprecated)
+				return loaded, err
+			},
+			expectedError: "TD_USER is deprecated since 2000-01-01, use TD_USERNAME",
+		},
+		{
+			name: "TestDeprecatedPrefix",
+			set: map[string]string{
+				"TDP_DATABASE_PASSWORD": "p",
+			},
+			get: func() (any, error) {
+				loaded, deprecations, err := loadDeprecated[TestDeprecatedPrefix](false)
+				assert.Equal(t, []env.Deprecation{
+					{Variable: "TDP_DATABASE_PASSWORD", Replacement: "TDP_DB_PASS"},
+				}, deprecations)
+				return loaded, err
+			},
+			check: func(t *testing.T, value any) {
+				actual := value.(TestDeprecatedPrefix)
+				assert.Equal(t, "p", actual.Conn.Pass)
+				assert.Equal(t, "sa", actual.Conn.User)
+			},
+		},
 	}
 
 	for _, testCase := range cases {
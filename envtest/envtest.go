@@ -0,0 +1,82 @@
+// Helpers for testing configuration structs without touching the process
+// environment, so tests can use t.Parallel.
+package envtest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/clickermonkey/env"
+)
+
+// Returns a loader which reads from the given variables only.
+func Loader(vars map[string]string) env.Loader {
+	return env.Loader{Source: env.MapSource(vars)}
+}
+
+// Loads the type from the given variables, failing the test on error.
+func Load[T any](t testing.TB, vars map[string]string) T {
+	t.Helper()
+	loaded, err := env.LoadWith[T](Loader(vars))
+	if err != nil {
+		t.Fatalf("loading %T: %v", loaded, err)
+	}
+	return loaded
+}
+
+// Loads the type from the given variables and returns the error.
+func LoadErr[T any](t testing.TB, vars map[string]string) (T, error) {
+	t.Helper()
+	return env.LoadWith[T](Loader(vars))
+}
+
+// Reads the .env formatted file from the testdata directory, failing the test on error.
+func Fixture(t testing.TB, name string) map[string]string {
+	t.Helper()
+	file, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("opening fixture: %v", err)
+	}
+	defer file.Close()
+	vars, err := env.ReadDotEnv(file)
+	if err != nil {
+		t.Fatalf("reading fixture %s: %v", name, err)
+	}
+	return vars
+}
+
+// Loads the type from the .env formatted file in the testdata directory, failing the test on error.
+func LoadFixture[T any](t testing.TB, name string) T {
+	t.Helper()
+	return Load[T](t, Fixture(t, name))
+}
+
+// Asserts the error has a FieldError for the variable which matches the
+// target error with errors.Is. A nil target matches any error.
+func AssertFieldError(t testing.TB, err error, variable string, target error) bool {
+	t.Helper()
+	if err == nil {
+		t.Errorf("expected error for %s, got none", variable)
+		return false
+	}
+	for _, fieldError := range env.FieldErrors(err) {
+		if slices.Contains(fieldError.Variables, variable) {
+			if target != nil && !errors.Is(fieldError.Err, target) {
+				t.Errorf("expected %s error to be %v, got %v", variable, target, fieldError.Err)
+				return false
+			}
+			return true
+		}
+	}
+	t.Errorf("expected error for %s, got %v", variable, err)
+	return false
+}
+
+// Asserts the error has a FieldError for the variable which is required.
+func AssertRequired(t testing.TB, err error, variable string) bool {
+	t.Helper()
+	return AssertFieldError(t, err, variable, env.ErrRequired)
+}
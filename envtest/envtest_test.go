@@ -0,0 +1,69 @@
+package envtest_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/clickermonkey/env/envtest"
+	"github.com/stretchr/testify/assert"
+)
+
+type App struct {
+	Name     string   `env:"APP_NAME"`
+	Port     int      `env:"APP_PORT" env-default:"80"`
+	Greeting string   `env:"APP_GREETING" env-default:"hi"`
+	Literal  string   `env:"APP_LITERAL" env-required:"false"`
+	Tags     []string `env:"APP_TAGS" env-required:"false"`
+	Database Database `env:"APP_DB_"`
+}
+
+type Database struct {
+	Host string `env:"HOST" env-default:"localhost"`
+	Port int    `env:"PORT" env-default:"5432"`
+}
+
+func TestLoad(t *testing.T) {
+	for i := range 5 {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			name := "app" + strconv.Itoa(i)
+			actual := envtest.Load[App](t, map[string]string{
+				"APP_NAME":    name,
+				"APP_DB_PORT": strconv.Itoa(i),
+			})
+			assert.Equal(t, name, actual.Name)
+			assert.Equal(t, 80, actual.Port)
+			assert.Equal(t, "localhost", actual.Database.Host)
+			assert.Equal(t, i, actual.Database.Port)
+		})
+	}
+}
+
+func TestLoadErr(t *testing.T) {
+	t.Parallel()
+
+	_, err := envtest.LoadErr[App](t, map[string]string{})
+	envtest.AssertRequired(t, err, "APP_NAME")
+
+	_, err = envtest.LoadErr[App](t, map[string]string{
+		"APP_NAME":    "name",
+		"APP_DB_PORT": "x",
+	})
+	envtest.AssertFieldError(t, err, "APP_DB_", nil)
+	envtest.AssertFieldError(t, err, "APP_DB_PORT", strconv.ErrSyntax)
+}
+
+func TestLoadFixture(t *testing.T) {
+	t.Parallel()
+
+	actual := envtest.LoadFixture[App](t, "app.env")
+	assert.Equal(t, App{
+		Name:     "fixture",
+		Port:     8080,
+		Greeting: "hello\tworld",
+		Literal:  `no\tescape`,
+		Tags:     []string{"a", "b", "c"},
+		Database: Database{Host: "localhost", Port: 5432},
+	}, actual)
+}
@@ -0,0 +1,42 @@
+package env
+
+import "strings"
+
+// An error parsing the field with the given environment variables.
+type FieldError struct {
+	Variables []string
+	Err       error
+}
+
+func (fe FieldError) Error() string {
+	return strings.Join(fe.Variables, EnvDelimiter) + ": " + fe.Err.Error()
+}
+
+func (fe FieldError) Unwrap() error {
+	return fe.Err
+}
+
+// Returns every FieldError in the chain of the error, outermost first.
+func FieldErrors(err error) []FieldError {
+	var fieldErrors []FieldError
+	for err != nil {
+		if fieldError, ok := err.(FieldError); ok {
+			fieldErrors = append(fieldErrors, fieldError)
+		}
+		err = unwrapOne(err)
+	}
+	return fieldErrors
+}
+
+// Unwraps a single error, following the first error of a joined error.
+func unwrapOne(err error) error {
+	switch wrapped := err.(type) {
+	case interface{ Unwrap() error }:
+		return wrapped.Unwrap()
+	case interface{ Unwrap() []error }:
+		if errs := wrapped.Unwrap(); len(errs) > 0 {
+			return errs[0]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// A custom parser for a family of types, given the type to parse.
+type TypeParser func(t reflect.Type, state UnmarshalState) (any, error)
+
+// A TypeParser for the types which match.
+type familyParser struct {
+	match  func(t reflect.Type) bool
+	parser TypeParser
+}
+
+// The parsers for families of types, the last registered match is used.
+var familyParsers []familyParser
+
+// Registers a custom parser for every type which matches. Parsers registered
+// for an exact type with RegisterParser take precedence.
+func RegisterParserWhen(match func(t reflect.Type) bool, parser TypeParser) {
+	familyParsers = append(familyParsers, familyParser{match: match, parser: parser})
+}
+
+// Registers a custom parser for every named type of the kind, ex: reflect.String
+// matches `type Code string` but not string itself. Use RegisterParserWhen to
+// match unnamed and built-in types of a kind too.
+func RegisterKindParser(kind reflect.Kind, parser TypeParser) {
+	RegisterParserWhen(func(t reflect.Type) bool {
+		return t.Kind() == kind && isNamed(t)
+	}, parser)
+}
+
+// Returns whether the type is declared by a package, which built-in and unnamed types are not.
+func isNamed(t reflect.Type) bool {
+	return t.Name() != "" && t.PkgPath() != ""
+}
+
+// Registers a custom parser for every type which implements the interface I,
+// directly or through a pointer. Pointers are parsed through their element.
+func RegisterInterfaceParser[I any](parser TypeParser) {
+	RegisterParserWhen(func(t reflect.Type) bool {
+		return t.Kind() != reflect.Pointer && hasInterface[I](t)
+	}, parser)
+}
+
+// Returns a match for every instantiation of the generic type of T, ex:
+// SameGeneric[Optional[any]]() matches Optional[int] and Optional[string].
+func SameGeneric[T any]() func(t reflect.Type) bool {
+	generic := reflect.TypeFor[T]()
+	name, isGeneric := genericName(generic)
+	if !isGeneric {
+		panic(fmt.Sprintf("env: SameGeneric requires a generic type, %v is not", generic))
+	}
+	return func(t reflect.Type) bool {
+		tName, tIsGeneric := genericName(t)
+		return tIsGeneric && tName == name && t.PkgPath() == generic.PkgPath()
+	}
+}
+
+// Returns the name of the type without its type arguments, and whether it has any.
+func genericName(t reflect.Type) (string, bool) {
+	name, _, isGeneric := strings.Cut(t.Name(), "[")
+	return name, isGeneric
+}
+
+// Returns the parser for the exact type or the last registered family which matches it.
+func parserFor(t reflect.Type) (Parser, bool) {
+	if parser, ok := parsers[t]; ok {
+		return parser, true
+	}
+	for i := len(familyParsers) - 1; i >= 0; i-- {
+		family := familyParsers[i]
+		if family.match(t) {
+			return func(state UnmarshalState) (any, error) {
+				return family.parser(t, state)
+			}, true
+		}
+	}
+	return nil, false
+}
+
+// Decodes the value (expected to be a pointer) with this state, as if it were
+// the value being parsed. This lets parsers of wrapper types delegate to the
+// decoder of their element.
+func (us UnmarshalState) Decode(value any) error {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("cannot decode into %T, expected a non-nil pointer", value)
+	}
+	return parse(rv.Elem(), us)
+}
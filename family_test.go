@@ -0,0 +1,147 @@
+package env_test
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/clickermonkey/env"
+	"github.com/stretchr/testify/assert"
+)
+
+// A named string type parsed by the kind parser, upper cased.
+type TestFamilyCode string
+
+// A named complex type parsed by the kind parser from "real:imag".
+type TestFamilyPoint complex128
+
+// A generic wrapper parsed by delegating to the element decoder.
+type TestFamilyList[T any] struct {
+	Items []T
+}
+
+// An interface family parsed by its interface.
+type TestFamilyNamed interface {
+	SetName(name string)
+}
+
+type TestFamilyTeam struct {
+	Name string
+}
+
+func (t *TestFamilyTeam) SetName(name string) {
+	t.Name = "team " + name
+}
+
+type TestFamilyUser struct {
+	Name string
+}
+
+func (u *TestFamilyUser) SetName(name string) {
+	u.Name = "user " + name
+}
+
+func init() {
+	env.RegisterParserWhen(func(t reflect.Type) bool {
+		return t.Kind() == reflect.String && t.PkgPath() != "" && strings.HasPrefix(t.Name(), "TestFamily")
+	}, func(t reflect.Type, state env.UnmarshalState) (any, error) {
+		value, exists := state.Read()
+		if !exists {
+			return nil, env.ErrMissing
+		}
+		return reflect.ValueOf(strings.ToUpper(value)).Convert(t).Interface(), nil
+	})
+	env.RegisterKindParser(reflect.Complex128, func(t reflect.Type, state env.UnmarshalState) (any, error) {
+		value, exists := state.Read()
+		if !exists {
+			return nil, env.ErrMissing
+		}
+		realText, imagText, _ := strings.Cut(value, ":")
+		parts := make([]float64, 2)
+		for i, text := range []string{realText, imagText} {
+			part, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, err
+			}
+			parts[i] = part
+		}
+		return reflect.ValueOf(complex(parts[0], parts[1])).Convert(t).Interface(), nil
+	})
+	env.RegisterParserWhen(env.SameGeneric[TestFamilyList[any]](), func(t reflect.Type, state env.UnmarshalState) (any, error) {
+		list := reflect.New(t)
+		if err := state.Decode(list.Elem().Field(0).Addr().Interface()); err != nil {
+			return nil, err
+		}
+		return list.Elem().Interface(), nil
+	})
+	env.RegisterInterfaceParser[TestFamilyNamed](func(t reflect.Type, state env.UnmarshalState) (any, error) {
+		value, exists := state.Read()
+		if !exists {
+			return nil, env.ErrMissing
+		}
+		named := reflect.New(t)
+		named.Interface().(TestFamilyNamed).SetName(value)
+		return named.Elem().Interface(), nil
+	})
+}
+
+type TestFamily struct {
+	Code  TestFamilyCode         `env:"CODE"`
+	Ports TestFamilyList[int]    `env:"PORTS"`
+	Names TestFamilyList[string] `env:"NAMES"`
+	Team  *TestFamilyTeam        `env:"TEAM"`
+	User  *TestFamilyUser        `env:"USER"`
+}
+
+func TestParserFamilies(t *testing.T) {
+	actual, err := env.LoadWith[TestFamily](env.Loader{Source: env.MapSource{
+		"CODE":  "abc",
+		"PORTS": "80,443",
+		"NAMES": "a,b",
+		"TEAM":  "core",
+		"USER":  "admin",
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, TestFamily{
+		Code:  "ABC",
+		Ports: TestFamilyList[int]{Items: []int{80, 443}},
+		Names: TestFamilyList[string]{Items: []string{"a", "b"}},
+		Team:  &TestFamilyTeam{Name: "team core"},
+		User:  &TestFamilyUser{Name: "user admin"},
+	}, actual)
+
+	_, err = env.LoadWith[TestFamily](env.Loader{Source: env.MapSource{
+		"CODE":  "abc",
+		"PORTS": "80,https",
+		"NAMES": "a",
+		"TEAM":  "core",
+		"USER":  "admin",
+	}})
+	var fieldErr env.FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, []string{"PORTS"}, fieldErr.Variables)
+	assert.ErrorContains(t, err, `at index 1: strconv.ParseInt: parsing "https": invalid syntax`)
+}
+
+type TestFamilyKind struct {
+	Point TestFamilyPoint `env:"POINT"`
+	Plain complex128      `env:"PLAIN"`
+}
+
+func TestKindParserNamedOnly(t *testing.T) {
+	actual, err := env.LoadWith[TestFamilyKind](env.Loader{Source: env.MapSource{
+		"POINT": "1:2",
+		"PLAIN": "(3+4i)",
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, TestFamilyKind{Point: TestFamilyPoint(1 + 2i), Plain: 3 + 4i}, actual)
+}
+
+func TestSameGeneric(t *testing.T) {
+	match := env.SameGeneric[TestFamilyList[any]]()
+	assert.True(t, match(reflect.TypeFor[TestFamilyList[int]]()))
+	assert.False(t, match(reflect.TypeFor[[]int]()))
+	assert.Panics(t, func() { env.SameGeneric[TestFamilyTeam]() })
+}
@@ -0,0 +1,145 @@
+package env
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// The separator between the keys of nested JSON objects and INI sections
+// when they're flattened into variable names, ex: {"db":{"host":""}} is DB_HOST.
+var FileKeySeparator = "_"
+
+// Reads variables from a file based on its extension: .json with ReadJSON,
+// .ini with ReadINI, and any other with ReadDotEnv.
+func ReadFile(path string) (MapSource, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var vars MapSource
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		vars, err = ReadJSON(file)
+	case ".ini":
+		vars, err = ReadINI(file)
+	default:
+		vars, err = ReadDotEnv(file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return vars, nil
+}
+
+// Reads variables from a JSON object. Nested objects are flattened into
+// upper case names joined by FileKeySeparator, ex: {"db":{"main":{"host":"x"}}}
+// is DB_MAIN_HOST=x. Arrays of values are joined by DefaultDelimiter, arrays
+// with objects are flattened by index, and null values are skipped.
+func ReadJSON(r io.Reader) (MapSource, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	var object map[string]any
+	if err := decoder.Decode(&object); err != nil {
+		return nil, fmt.Errorf("invalid JSON object: %w", err)
+	}
+	vars := make(MapSource)
+	flattenJSON("", object, vars)
+	return vars, nil
+}
+
+// Adds the value to vars under the name, flattening objects & arrays.
+func flattenJSON(name string, value any, vars MapSource) {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, child := range value {
+			flattenJSON(joinFileKey(name, key), child, vars)
+		}
+	case []any:
+		elements := make([]string, 0, len(value))
+		for _, element := range value {
+			switch element.(type) {
+			case map[string]any, []any:
+				for i, element := range value {
+					flattenJSON(joinFileKey(name, strconv.Itoa(i)), element, vars)
+				}
+				return
+			}
+			elements = append(elements, jsonText(element))
+		}
+		vars[name] = strings.Join(elements, DefaultDelimiter)
+	case nil:
+	default:
+		vars[name] = jsonText(value)
+	}
+}
+
+// Returns the text of a JSON string, number, or boolean.
+func jsonText(value any) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case bool:
+		return strconv.FormatBool(value)
+	}
+	return fmt.Sprint(value)
+}
+
+// Reads variables from an INI file. Sections are upper case prefixes joined by
+// FileKeySeparator, ex: host=x in [db.main] is DB_MAIN_HOST=x. Lines starting
+// with ; or # are comments and values may be quoted like ReadDotEnv.
+func ReadINI(r io.Reader) (MapSource, error) {
+	vars := make(MapSource)
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	section := ""
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: expected [section]", lineNumber)
+			}
+			section = ""
+			for _, key := range strings.Split(line[1:len(line)-1], ".") {
+				section = joinFileKey(section, strings.TrimSpace(key))
+			}
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("line %d: expected key=value", lineNumber)
+		}
+		value, err := readValue(value, " ;", " #")
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		vars[joinFileKey(section, key)] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return vars, nil
+}
+
+// Joins the upper case key to the name with FileKeySeparator.
+func joinFileKey(name string, key string) string {
+	key = strings.ToUpper(key)
+	if name == "" {
+		return key
+	}
+	return name + FileKeySeparator + key
+}
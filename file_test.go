@@ -0,0 +1,111 @@
+package env_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/clickermonkey/env"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestFileDatabase struct {
+	Host string `env:"HOST"`
+	Port int    `env:"PORT"`
+}
+
+type TestFile struct {
+	Name    string           `env:"NAME"`
+	Debug   bool             `env:"DEBUG"`
+	Tags    []string         `env:"TAGS"`
+	Main    TestFileDatabase `env:"DB_MAIN_"`
+	Replica TestFileDatabase `env:"DB_REPLICA_"`
+}
+
+func TestReadJSON(t *testing.T) {
+	vars, err := env.ReadJSON(strings.NewReader(`{
+		"name": "app",
+		"debug": true,
+		"tags": ["a", "b"],
+		"db": {
+			"main": {"host": "main", "port": 5432},
+			"replica": {"host": "replica", "port": 5433}
+		},
+		"servers": [{"host": "a"}, {"host": "b"}],
+		"missing": null
+	}`))
+	assert.NoError(t, err)
+	assert.Equal(t, env.MapSource{
+		"NAME":            "app",
+		"DEBUG":           "true",
+		"TAGS":            "a,b",
+		"DB_MAIN_HOST":    "main",
+		"DB_MAIN_PORT":    "5432",
+		"DB_REPLICA_HOST": "replica",
+		"DB_REPLICA_PORT": "5433",
+		"SERVERS_0_HOST":  "a",
+		"SERVERS_1_HOST":  "b",
+	}, vars)
+
+	_, err = env.ReadJSON(strings.NewReader(`[1, 2]`))
+	assert.ErrorContains(t, err, "invalid JSON object")
+}
+
+func TestReadINI(t *testing.T) {
+	vars, err := env.ReadINI(strings.NewReader(`
+; global settings
+name = app
+debug = true ; inline comment
+
+[db.main]
+host = "main"
+port = 5432
+
+[db.replica]
+# replica settings
+host = 'replica'
+port = 5433
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, env.MapSource{
+		"NAME":            "app",
+		"DEBUG":           "true",
+		"DB_MAIN_HOST":    "main",
+		"DB_MAIN_PORT":    "5432",
+		"DB_REPLICA_HOST": "replica",
+		"DB_REPLICA_PORT": "5433",
+	}, vars)
+
+	_, err = env.ReadINI(strings.NewReader("[db\nhost=x"))
+	assert.EqualError(t, err, "line 1: expected [section]")
+}
+
+func TestReadFileLayers(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{
+		"name": "file",
+		"debug": false,
+		"tags": ["a"],
+		"db": {"main": {"host": "main", "port": 5432}, "replica": {"host": "replica", "port": 5433}}
+	}`), 0o600))
+
+	file, err := env.ReadFile(path)
+	assert.NoError(t, err)
+
+	actual, err := env.LoadWith[TestFile](env.Loader{Source: env.Layers{
+		env.MapSource{"NAME": "env", "DB_MAIN_HOST": "env-main"},
+		file,
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, TestFile{
+		Name:    "env",
+		Tags:    []string{"a"},
+		Main:    TestFileDatabase{Host: "env-main", Port: 5432},
+		Replica: TestFileDatabase{Host: "replica", Port: 5433},
+	}, actual)
+
+	_, err = env.ReadFile(filepath.Join(dir, "missing.ini"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,164 @@
+package env
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+var (
+	// The struct tag which defines the name of the flag bound by BindFlags, ex: `env-flag:"port"`.
+	// Skip (-) doesn't bind a flag.
+	TagEnvFlag = "env-flag"
+
+	// The struct tag which describes a field, used as the usage of its flag.
+	TagEnvDescription = "env-description"
+)
+
+// Registers a flag on the flag set for every field of the value (expected to
+// be a pointer to a struct). The flag name comes from TagEnvFlag or the
+// variable name, ex: DB_HOST is db-host. Flags which are set take precedence
+// over variables when loaded with Loader{Flags: fs}.
+func BindFlags(fs *flag.FlagSet, value any) error {
+	return Loader{}.BindFlags(fs, value)
+}
+
+// Registers a flag on the flag set for every field of the value, with the
+// variable names of this loader.
+func (l Loader) BindFlags(fs *flag.FlagSet, value any) error {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("cannot bind flags to %T, expected a pointer to a struct", value)
+	}
+	return l.bindFlags(fs, rv.Type().Elem(), l.rootState())
+}
+
+// Registers a flag for every field of the struct type, nested structs are
+// bound with their prefix.
+func (l Loader) bindFlags(fs *flag.FlagSet, structType reflect.Type, state UnmarshalState) error {
+	for i := range structType.NumField() {
+		fieldStruct := structType.Field(i)
+		if !fieldStruct.IsExported() && !fieldStruct.Anonymous {
+			continue
+		}
+		fieldState, skip := newFieldState(fieldStruct, state)
+		if skip || len(fieldState.Variables) == 0 {
+			continue
+		}
+		fieldType := fieldStruct.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if fieldState.parsesFields(fieldStruct.Type) {
+			if err := l.bindFlags(fs, fieldType, fieldState); err != nil {
+				return err
+			}
+			continue
+		}
+		if fieldState.parsesImpl(fieldType) {
+			continue
+		}
+		variable := fieldState.Variables[0]
+		name, _ := fieldState.Tag(TagEnvFlag, flagName(strings.TrimPrefix(variable, l.Prefix)))
+		if name == Skip {
+			continue
+		}
+		description, _ := fieldState.Tag(TagEnvDescription, "")
+		usage := fmt.Sprintf("%s (env %s)", description, variable)
+		if description == "" {
+			usage = fmt.Sprintf("(env %s)", variable)
+		}
+		fs.Var(&flagValue{
+			variable:  variable,
+			fieldType: fieldStruct.Type,
+			state:     fieldState,
+			isBool:    fieldType.Kind() == reflect.Bool,
+		}, name, usage+defaultsUsage(fieldState.Defaults()))
+	}
+	return nil
+}
+
+// Returns the usage of the defaults of every profile, ex: (default 8080, prod: 80).
+// The active profile is only known when loaded, so none is chosen.
+func defaultsUsage(defaults map[string]string) string {
+	if len(defaults) == 0 {
+		return ""
+	}
+	quote := func(value string) string {
+		if value == "" {
+			return `""`
+		}
+		return value
+	}
+	var parts []string
+	if value, exists := defaults[""]; exists {
+		parts = append(parts, quote(value))
+	}
+	profiles := make([]string, 0, len(defaults))
+	for profile := range defaults {
+		if profile != "" {
+			profiles = append(profiles, profile)
+		}
+	}
+	slices.Sort(profiles)
+	for _, profile := range profiles {
+		parts = append(parts, profile+": "+quote(defaults[profile]))
+	}
+	return " (default " + strings.Join(parts, ", ") + ")"
+}
+
+// Returns the flag name for a variable, ex: DB_HOST is db-host.
+func flagName(variable string) string {
+	return strings.ReplaceAll(strings.ToLower(variable), "_", "-")
+}
+
+// The value of a flag bound to a variable, parsed when loaded. The value is
+// checked when set so flag reports an invalid value for the flag.
+type flagValue struct {
+	variable  string
+	fieldType reflect.Type
+	state     UnmarshalState
+	text      string
+	set       bool
+	isBool    bool
+}
+
+func (f *flagValue) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.text
+}
+
+func (f *flagValue) Set(text string) error {
+	// Parsed into a temporary with the field's state, the value is parsed again when loaded.
+	if value, exists := f.state.applyEmpty(text, true); exists {
+		state := f.state
+		state.read = &value
+		state.readExists = true
+		if err := parse(reflect.New(f.fieldType).Elem(), state); err != nil && !errors.Is(err, ErrMissing) {
+			return err
+		}
+	}
+	f.text = text
+	f.set = true
+	return nil
+}
+
+func (f *flagValue) IsBoolFlag() bool {
+	return f.isBool
+}
+
+// Returns the variables of the flags which were set.
+func flagSource(fs *flag.FlagSet) MapSource {
+	vars := make(MapSource)
+	fs.Visit(func(f *flag.Flag) {
+		if value, ok := f.Value.(*flagValue); ok && value.set {
+			vars[value.variable] = value.text
+		}
+	})
+	return vars
+}
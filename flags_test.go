@@ -0,0 +1,93 @@
+package env_test
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/clickermonkey/env"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestFlagsDatabase struct {
+	Host string `env:"HOST" env-description:"database host"`
+}
+
+type TestFlags struct {
+	Port     int               `env:"PORT" env-default:"8080" env-description:"port to listen on"`
+	Verbose  bool              `env:"VERBOSE" env-flag:"v" env-required:"false"`
+	Name     string            `env:"APP_NAME" env-default:"app"`
+	Token    string            `env:"TOKEN" env-flag:"-" env-required:"false"`
+	Database TestFlagsDatabase `env:"DB_"`
+}
+
+func TestBindFlags(t *testing.T) {
+	var config TestFlags
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	assert.NoError(t, env.BindFlags(fs, &config))
+	assert.NoError(t, fs.Parse([]string{"-port", "9090", "-v", "-db-host", "flag-db"}))
+
+	err := env.Loader{Flags: fs, Source: env.MapSource{
+		"PORT":     "7070",
+		"APP_NAME": "env-app",
+		"DB_HOST":  "env-db",
+	}}.Parse(&config)
+	assert.NoError(t, err)
+	assert.Equal(t, TestFlags{
+		Port:     9090,
+		Verbose:  true,
+		Name:     "env-app",
+		Database: TestFlagsDatabase{Host: "flag-db"},
+	}, config)
+
+	config = TestFlags{}
+	err = env.Loader{Flags: fs, Source: env.MapSource{}}.Parse(&config)
+	assert.NoError(t, err)
+	assert.Equal(t, "app", config.Name)
+
+	assert.Nil(t, fs.Lookup("token"))
+}
+
+func TestBindFlagsUsage(t *testing.T) {
+	var config TestFlags
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	assert.NoError(t, env.BindFlags(fs, &config))
+
+	var usage strings.Builder
+	fs.SetOutput(&usage)
+	fs.PrintDefaults()
+	assert.Contains(t, usage.String(), "-port value\n    \tport to listen on (env PORT) (default 8080)")
+	assert.Contains(t, usage.String(), "-app-name value\n    \t(env APP_NAME) (default app)")
+	assert.Contains(t, usage.String(), "-db-host value\n    \tdatabase host (env DB_HOST)")
+	assert.Contains(t, usage.String(), "-v\t(env VERBOSE)")
+}
+
+func TestBindFlagsInvalid(t *testing.T) {
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	assert.Error(t, env.BindFlags(fs, TestFlags{}))
+}
+
+type TestFlagsValues struct {
+	Level  int               `env:"LEVEL" env-default:"1" env-default-prod:"3" env-default-dev:""`
+	Config TestFlagsDatabase `env:"CONFIG" env-format:"json" env-required:"false"`
+}
+
+func TestBindFlagsValues(t *testing.T) {
+	var config TestFlagsValues
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	assert.NoError(t, env.BindFlags(fs, &config))
+	assert.Nil(t, fs.Lookup("config-host"))
+
+	var usage strings.Builder
+	fs.SetOutput(&usage)
+	fs.PrintDefaults()
+	assert.Contains(t, usage.String(), `-level value`+"\n    \t"+`(env LEVEL) (default 1, dev: "", prod: 3)`)
+
+	err := fs.Parse([]string{"-level", "high"})
+	assert.ErrorContains(t, err, `invalid value "high" for flag -level: `)
+
+	assert.NoError(t, fs.Parse([]string{"-level", "2", "-config", `{"Host":"flag-db"}`}))
+	err = env.Loader{Flags: fs, Source: env.MapSource{}}.Parse(&config)
+	assert.NoError(t, err)
+	assert.Equal(t, TestFlagsValues{Level: 2, Config: TestFlagsDatabase{Host: "flag-db"}}, config)
+}
@@ -0,0 +1,90 @@
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Decodes data into the value, which is a pointer, ex: json.Unmarshal.
+type FormatUnmarshal func(data []byte, value any) error
+
+// Encodes the value into data, ex: json.Marshal.
+type FormatMarshal func(value any) ([]byte, error)
+
+type format struct {
+	unmarshal FormatUnmarshal
+	marshal   FormatMarshal
+}
+
+var (
+	formats map[string]format
+
+	// The struct tag which defines the format of the whole value, ex: `env-format:"json"`.
+	// The value is decoded by the format instead of being split or parsed by type.
+	TagEnvFormat = "env-format"
+)
+
+// Registers a format which can be used in the TagEnvFormat struct tag, ex:
+//
+//	env.RegisterFormat("yaml", yaml.Unmarshal, yaml.Marshal)
+func RegisterFormat(name string, unmarshal FormatUnmarshal, marshal FormatMarshal) {
+	formats[name] = format{unmarshal: unmarshal, marshal: marshal}
+}
+
+// Registers the built-in formats.
+func registerFormats() {
+	RegisterFormat("json", json.Unmarshal, json.Marshal)
+}
+
+// Returns the name of the format in the TagEnvFormat struct tag, if any.
+func (us UnmarshalState) Format() (string, bool) {
+	return us.Tag(TagEnvFormat, "")
+}
+
+// Returns the registered format by name.
+func getFormat(name string) (format, error) {
+	found, exists := formats[name]
+	if !exists {
+		names := make([]string, 0, len(formats))
+		for known := range formats {
+			names = append(names, known)
+		}
+		slices.Sort(names)
+		return format{}, fmt.Errorf("unknown %s %q, expected one of %s", TagEnvFormat, name, strings.Join(names, ", "))
+	}
+	return found, nil
+}
+
+// Parses the whole value with the named format.
+func parseFormat(rv reflect.Value, state UnmarshalState, name string) error {
+	found, err := getFormat(name)
+	if err != nil {
+		return err
+	}
+	text, exists := state.Read()
+	if !exists {
+		return ErrMissing
+	}
+	target := reflect.New(rv.Type())
+	if err := found.unmarshal([]byte(text), target.Interface()); err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+	rv.Set(target.Elem())
+	return nil
+}
+
+// Formats the whole value with the named format.
+func formatFormat(rv reflect.Value, name string) (string, error) {
+	found, err := getFormat(name)
+	if err != nil {
+		return "", err
+	}
+	data, err := found.marshal(rv.Interface())
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,62 @@
+package env_test
+
+import (
+	"testing"
+
+	"github.com/clickermonkey/env"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestFormatLimits struct {
+	Max  int    `json:"max"`
+	Name string `json:"name"`
+}
+
+type TestFormat struct {
+	Flags   map[string]bool   `env:"FLAGS" env-format:"json"`
+	Allowed []string          `env:"ALLOWED" env-format:"json"`
+	Limits  *TestFormatLimits `env:"LIMITS" env-format:"json"`
+	Any     any               `env:"ANY" env-format:"json" env-required:"false"`
+}
+
+func TestFormats(t *testing.T) {
+	actual, err := env.LoadWith[TestFormat](env.Loader{Source: env.MapSource{
+		"FLAGS":   `{"a":true,"b":false}`,
+		"ALLOWED": `["x","y,z"]`,
+		"LIMITS":  `{"max":3,"name":"n"}`,
+		"ANY":     `[1,"two"]`,
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, TestFormat{
+		Flags:   map[string]bool{"a": true, "b": false},
+		Allowed: []string{"x", "y,z"},
+		Limits:  &TestFormatLimits{Max: 3, Name: "n"},
+		Any:     []any{float64(1), "two"},
+	}, actual)
+
+	vars, err := env.Marshal(actual)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"FLAGS":   `{"a":true,"b":false}`,
+		"ALLOWED": `["x","y,z"]`,
+		"LIMITS":  `{"max":3,"name":"n"}`,
+		"ANY":     `[1,"two"]`,
+	}, vars)
+}
+
+func TestFormatsErrors(t *testing.T) {
+	_, err := env.LoadWith[TestFormat](env.Loader{Source: env.MapSource{
+		"FLAGS": `{"a":1}`,
+	}})
+	assert.ErrorContains(t, err, "FLAGS: invalid json: json: cannot unmarshal number")
+
+	_, err = env.LoadWith[TestFormat](env.Loader{Source: env.MapSource{
+		"FLAGS": `{}`,
+	}})
+	assert.EqualError(t, err, "ALLOWED: required")
+
+	_, err = env.LoadWith[struct {
+		Value []string `env:"VALUE" env-format:"toml"`
+	}](env.Loader{Source: env.MapSource{"VALUE": "x"}})
+	assert.EqualError(t, err, `VALUE: unknown env-format "toml", expected one of json`)
+}
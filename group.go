@@ -0,0 +1,185 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// A rule for how many fields in a group may be present in the environment.
+type GroupRule string
+
+const (
+	// Exactly one field in the group must be present.
+	GroupExactlyOne GroupRule = "exactly-one"
+	// No more than one field in the group may be present, it's mutually exclusive.
+	GroupAtMostOne GroupRule = "at-most-one"
+	// One or more fields in the group must be present.
+	GroupAtLeastOne GroupRule = "at-least-one"
+)
+
+// The rule used when no field in a group specifies one.
+var DefaultGroupRule = GroupAtMostOne
+
+// An error returned when the fields in a group break the group's rule.
+// Variables are the variables found when too many fields were given,
+// otherwise the variables of every field in the group.
+type GroupError struct {
+	Group     string
+	Rule      GroupRule
+	Variables []string
+	Present   int
+}
+
+func (ge GroupError) Error() string {
+	if ge.Present == 0 {
+		return fmt.Sprintf("%s is required", joinNames(ge.Variables, "or"))
+	}
+	return fmt.Sprintf("%s are mutually exclusive", joinNames(ge.Variables, "and"))
+}
+
+// A missing group is treated like any other missing required value.
+func (ge GroupError) Unwrap() error {
+	if ge.Present == 0 {
+		return ErrRequired
+	}
+	return nil
+}
+
+// A group of fields in a struct being parsed.
+type fieldGroup struct {
+	name      string
+	rule      GroupRule
+	variables []string
+	found     []string
+}
+
+// The groups defined on the fields of a struct, in order of first appearance.
+type fieldGroups []*fieldGroup
+
+// Adds the field to its group with the variables which make it present and the
+// variable found, if any. A rule defined on the field must match any rule
+// defined on another field of the group.
+func (groups *fieldGroups) add(name string, rule GroupRule, variables []string, found string) error {
+	var group *fieldGroup
+	for _, existing := range *groups {
+		if existing.name == name {
+			group = existing
+			break
+		}
+	}
+	if group == nil {
+		group = &fieldGroup{name: name}
+		*groups = append(*groups, group)
+	}
+	if rule != "" {
+		if group.rule != "" && group.rule != rule {
+			return fmt.Errorf("%s group %s has conflicting rules %s and %s", TagEnvGroup, name, group.rule, rule)
+		}
+		group.rule = rule
+	}
+	group.variables = append(group.variables, variables...)
+	if found != "" {
+		group.found = append(group.found, found)
+	}
+	return nil
+}
+
+// Checks each group against its rule and returns the first violation.
+func (groups fieldGroups) check() error {
+	for _, group := range groups {
+		rule := group.rule
+		if rule == "" {
+			rule = DefaultGroupRule
+		}
+
+		present := len(group.found)
+		tooMany := present > 1 && (rule == GroupExactlyOne || rule == GroupAtMostOne)
+		tooFew := present == 0 && (rule == GroupExactlyOne || rule == GroupAtLeastOne)
+		if tooMany {
+			return GroupError{Group: group.name, Rule: rule, Variables: group.found, Present: present}
+		}
+		if tooFew {
+			return GroupError{Group: group.name, Rule: rule, Variables: group.variables}
+		}
+	}
+	return nil
+}
+
+// Returns the group name and rule specified in the TagEnvGroup struct tag.
+// The tag is a group name optionally followed by a comma and a GroupRule.
+func (us UnmarshalState) Group() (name string, rule GroupRule, err error) {
+	tag, exists := us.Tag(TagEnvGroup, "")
+	if !exists || tag == "" {
+		return
+	}
+	name, ruleText, _ := strings.Cut(tag, ",")
+	rule = GroupRule(strings.TrimSpace(ruleText))
+	switch rule {
+	case "", GroupExactlyOne, GroupAtMostOne, GroupAtLeastOne:
+	default:
+		err = fmt.Errorf("unknown %s rule %q", TagEnvGroup, rule)
+	}
+	name = strings.TrimSpace(name)
+	return
+}
+
+// Returns the first variable of the field of the given type which was found,
+// defaults don't count. Structs are found through any of their fields.
+func (us UnmarshalState) found(t reflect.Type) (found string, exists bool) {
+	us.eachValue(t, make(map[reflect.Type]bool), func(value UnmarshalState) bool {
+		found, exists = value.foundVariable()
+		return !exists
+	})
+	return
+}
+
+// Returns the variables of the field of the given type, any of which makes it
+// present. Structs have the variables of their fields.
+func (us UnmarshalState) valueVariables(t reflect.Type) []string {
+	var variables []string
+	us.eachValue(t, make(map[reflect.Type]bool), func(value UnmarshalState) bool {
+		variables = append(variables, value.String())
+		return true
+	})
+	return variables
+}
+
+// Calls fn with the state of every value read for the field of the given type,
+// the fields of structs and the TypeVariable of interfaces, until fn returns false.
+func (us UnmarshalState) eachValue(t reflect.Type, visited map[reflect.Type]bool, fn func(value UnmarshalState) bool) bool {
+	if !us.parsesFields(t) {
+		if us.parsesImpl(t) {
+			us = us.typeState()
+		}
+		return fn(us)
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	// Recursive types have the values of their first occurrence.
+	if visited[t] {
+		return true
+	}
+	visited[t] = true
+	defer delete(visited, t)
+	for i := range t.NumField() {
+		fieldStruct := t.Field(i)
+		if !fieldStruct.IsExported() && !fieldStruct.Anonymous {
+			continue
+		}
+		fieldState, skip := newFieldState(fieldStruct, us)
+		if !skip && !fieldState.eachValue(fieldStruct.Type, visited, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// Joins names into a readable list, "A, B and C".
+func joinNames(names []string, conjunction string) string {
+	if len(names) <= 1 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " " + conjunction + " " + names[len(names)-1]
+}
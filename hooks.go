@@ -0,0 +1,62 @@
+package env
+
+import (
+	"reflect"
+)
+
+// A struct which sets computed defaults before its fields are parsed. Fields
+// given a non-zero value are not required. The defaults of a struct are set
+// before those of its nested and embedded structs. The hooks of an embedded
+// struct are promoted, so a struct which overrides them should call them.
+type Defaulter interface {
+	SetEnvDefaults()
+}
+
+// A struct which derives fields or checks fields against each other after
+// its fields are parsed, even when none of its variables exist. Nested and
+// embedded structs are loaded before the struct containing them. A struct
+// behind a nil pointer is left nil when none of its variables exist, so it's
+// not called then.
+type AfterLoader interface {
+	AfterEnvLoad() error
+}
+
+// Sets the computed defaults of the struct, returns whether it has any.
+// Promoted defaults were already set by the struct containing it.
+func setDefaults(rv reflect.Value, state UnmarshalState) bool {
+	if state.promotedDefaults {
+		return true
+	}
+	defaulter, ok := asInterface[Defaulter](rv)
+	if ok {
+		defaulter.SetEnvDefaults()
+	}
+	return ok
+}
+
+// Calls the AfterLoader of the struct, if any.
+func afterLoad(rv reflect.Value, state UnmarshalState) error {
+	if state.promotedAfterLoad {
+		return nil
+	}
+	if afterLoader, ok := asInterface[AfterLoader](rv); ok {
+		return afterLoader.AfterEnvLoad()
+	}
+	return nil
+}
+
+// Marks the hooks of an embedded field which are promoted to (or overridden
+// by) the struct containing it, so they are only called once.
+func (us *UnmarshalState) promoteHooks(outer reflect.Type, field reflect.StructField) {
+	if !field.Anonymous {
+		return
+	}
+	us.promotedDefaults = hasInterface[Defaulter](outer) && hasInterface[Defaulter](field.Type)
+	us.promotedAfterLoad = hasInterface[AfterLoader](outer) && hasInterface[AfterLoader](field.Type)
+}
+
+// Returns whether the type or a pointer to it implements the interface.
+func hasInterface[I any](t reflect.Type) bool {
+	i := reflect.TypeFor[I]()
+	return t.Implements(i) || (t.Kind() != reflect.Pointer && reflect.PointerTo(t).Implements(i))
+}
@@ -0,0 +1,138 @@
+package env_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/clickermonkey/env"
+	"github.com/stretchr/testify/assert"
+)
+
+var hookCalls []string
+
+type TestHooksServer struct {
+	Host string `env:"HOST"`
+	Port int    `env:"PORT"`
+	URL  string `env:"-"`
+}
+
+func (s *TestHooksServer) SetEnvDefaults() {
+	hookCalls = append(hookCalls, "server defaults")
+	s.Port = 8080
+}
+
+func (s *TestHooksServer) AfterEnvLoad() error {
+	hookCalls = append(hookCalls, "server loaded")
+	s.URL = "http://" + s.Host
+	return nil
+}
+
+type TestHooksLogging struct {
+	Level string `env:"LOG_LEVEL"`
+}
+
+func (l *TestHooksLogging) SetEnvDefaults() {
+	hookCalls = append(hookCalls, "logging defaults")
+	l.Level = "info"
+}
+
+func (l *TestHooksLogging) AfterEnvLoad() error {
+	hookCalls = append(hookCalls, "logging loaded")
+	return nil
+}
+
+type TestHooks struct {
+	TestHooksLogging
+	Server  TestHooksServer  `env:"SERVER_"`
+	Replica *TestHooksServer `env:"REPLICA_"`
+	Workers int              `env:"WORKERS"`
+}
+
+func (h *TestHooks) AfterEnvLoad() error {
+	if err := h.TestHooksLogging.AfterEnvLoad(); err != nil {
+		return err
+	}
+	hookCalls = append(hookCalls, "config loaded")
+	if h.Workers > 10 {
+		return errors.New("too many workers")
+	}
+	return nil
+}
+
+func TestHooksOrder(t *testing.T) {
+	hookCalls = nil
+	actual, err := env.LoadWith[TestHooks](env.Loader{Source: env.MapSource{
+		"SERVER_HOST":  "localhost",
+		"REPLICA_HOST": "replica",
+		"REPLICA_PORT": "9090",
+		"WORKERS":      "4",
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, TestHooks{
+		TestHooksLogging: TestHooksLogging{Level: "info"},
+		Server:           TestHooksServer{Host: "localhost", Port: 8080, URL: "http://localhost"},
+		Replica:          &TestHooksServer{Host: "replica", Port: 9090, URL: "http://replica"},
+		Workers:          4,
+	}, actual)
+	assert.Equal(t, []string{
+		"logging defaults",
+		"server defaults",
+		"server loaded",
+		"server defaults",
+		"server loaded",
+		"logging loaded",
+		"config loaded",
+	}, hookCalls)
+}
+
+func TestHooksPromoted(t *testing.T) {
+	type Config struct {
+		TestHooksLogging
+		Name string `env:"NAME"`
+	}
+	hookCalls = nil
+	actual, err := env.LoadWith[Config](env.Loader{Source: env.MapSource{"NAME": "n"}})
+	assert.NoError(t, err)
+	assert.Equal(t, Config{TestHooksLogging: TestHooksLogging{Level: "info"}, Name: "n"}, actual)
+	assert.Equal(t, []string{"logging defaults", "logging loaded"}, hookCalls)
+}
+
+func TestHooksAfterLoadError(t *testing.T) {
+	_, err := env.LoadWith[TestHooks](env.Loader{Source: env.MapSource{
+		"SERVER_HOST": "localhost",
+		"WORKERS":     "40",
+	}})
+	assert.EqualError(t, err, "too many workers")
+}
+
+type TestHooksOptional struct {
+	Host *string `env:"HOST"`
+	URL  string  `env:"-"`
+}
+
+func (h *TestHooksOptional) AfterEnvLoad() error {
+	hookCalls = append(hookCalls, "optional loaded")
+	h.URL = "http://default"
+	if h.Host != nil {
+		h.URL = "http://" + *h.Host
+	}
+	return nil
+}
+
+func TestHooksAllMissing(t *testing.T) {
+	hookCalls = nil
+	actual, err := env.LoadWith[TestHooksOptional](env.Loader{Source: env.MapSource{}})
+	assert.NoError(t, err)
+	assert.Equal(t, "http://default", actual.URL)
+
+	type Config struct {
+		Optional TestHooksOptional  `env:"OPTIONAL_" env-required:"false"`
+		Pointer  *TestHooksOptional `env:"POINTER_"`
+	}
+	hookCalls = nil
+	config, err := env.LoadWith[Config](env.Loader{Source: env.MapSource{}})
+	assert.NoError(t, err)
+	assert.Equal(t, "http://default", config.Optional.URL)
+	assert.Nil(t, config.Pointer)
+	assert.Equal(t, []string{"optional loaded"}, hookCalls)
+}
@@ -0,0 +1,114 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+var (
+	// The implementations of interfaces by their name.
+	impls map[reflect.Type]map[string]reflect.Type
+
+	// The variable under the prefix of an interface field which names its
+	// implementation, ex: STORE_TYPE=redis for `env:"STORE_"`.
+	TypeVariable = "TYPE"
+)
+
+// Registers an implementation of the interface I under the name, so an
+// interface field reads the name from TypeVariable and parses the
+// implementation under the same prefix, ex: RegisterImpl[Store]("redis", RedisStore{}).
+func RegisterImpl[I any](name string, impl I) {
+	key := reflect.TypeFor[I]()
+	if key.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("env: RegisterImpl requires an interface type, %v is not", key))
+	}
+	implType := reflect.TypeOf(impl)
+	if implType == nil {
+		panic(fmt.Sprintf("env: RegisterImpl of %v requires a non-nil implementation", key))
+	}
+	if impls[key] == nil {
+		impls[key] = make(map[string]reflect.Type)
+	}
+	impls[key][name] = implType
+}
+
+// Returns the names of the implementations registered for the interface type, in sorted order.
+func ImplNames(t reflect.Type) []string {
+	names := make([]string, 0, len(impls[t]))
+	for name := range impls[t] {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Returns the state of the TypeVariable of an interface field.
+func (us UnmarshalState) typeState() UnmarshalState {
+	typeState := us
+	typeState.Variables = joinVariables(us.Variables, []string{TypeVariable})
+	if len(us.Deprecated) > 0 {
+		typeState.Deprecated = joinVariables(us.Deprecated, []string{TypeVariable})
+	}
+	return typeState
+}
+
+// Returns whether the field of the given type is parsed as the implementation
+// named by its TypeVariable, which an interface with a TagEnvFormat struct tag is not.
+func (us UnmarshalState) parsesImpl(t reflect.Type) bool {
+	_, hasFormat := us.Format()
+	return !hasFormat && t.Kind() == reflect.Interface
+}
+
+// Parses an interface by reading the name of its implementation and parsing
+// the implementation under the same prefix. Errors of the TypeVariable are
+// returned as a FieldError of it, even when it's missing.
+func parseImpl(rv reflect.Value, state UnmarshalState) error {
+	typeState := state.typeState()
+	if err := typeState.checkAmbiguous(); err != nil {
+		return FieldError{Variables: typeState.Variables, Err: err}
+	}
+	text, exists := typeState.Read()
+	if !exists {
+		return FieldError{Variables: typeState.Variables, Err: ErrMissing}
+	}
+	name, err := matchEnum(text, ImplNames(rv.Type()))
+	if err != nil {
+		return FieldError{Variables: typeState.Variables, Err: err}
+	}
+	implType := impls[rv.Type()][name]
+	impl := reflect.New(implType).Elem()
+	if implType.Kind() == reflect.Pointer {
+		impl.Set(reflect.New(implType.Elem()))
+	}
+	// The implementation was chosen, so it's valid even when none of its variables exist.
+	if err := parse(impl, state); err != nil && !errors.Is(err, ErrMissing) {
+		return err
+	}
+	rv.Set(impl)
+	return nil
+}
+
+// Formats an interface as the name of its implementation and the implementation
+// under the same prefix.
+func (o EnvironOptions) formatImpl(rv reflect.Value, state UnmarshalState, out map[string]string) error {
+	if rv.IsNil() {
+		return nil
+	}
+	impl := rv.Elem()
+	for name, implType := range impls[rv.Type()] {
+		if implType != impl.Type() {
+			continue
+		}
+		if err := o.format(reflect.ValueOf(name), state.typeState(), out); err != nil {
+			return err
+		}
+		// Copied so pointer receivers of the implementation can be used.
+		addressable := reflect.New(impl.Type()).Elem()
+		addressable.Set(impl)
+		_, _, err := o.formatText(addressable, state, out)
+		return err
+	}
+	return fmt.Errorf("%v is not a registered implementation of %v", impl.Type(), rv.Type())
+}
@@ -0,0 +1,105 @@
+package env_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/clickermonkey/env"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestStore interface {
+	Name() string
+}
+
+type TestRedisStore struct {
+	Addr string `env:"ADDR"`
+	DB   int    `env:"DB" env-default:"0"`
+}
+
+func (s TestRedisStore) Name() string { return "redis" }
+
+type TestMemoryStore struct {
+	Size int `env:"SIZE" env-required:"false"`
+}
+
+func (s *TestMemoryStore) Name() string { return "memory" }
+
+func init() {
+	env.RegisterImpl[TestStore]("redis", TestRedisStore{})
+	env.RegisterImpl[TestStore]("memory", &TestMemoryStore{})
+}
+
+type TestImpl struct {
+	Store TestStore `env:"STORE_"`
+	Cache TestStore `env:"CACHE_" env-default:"memory"`
+}
+
+func TestImplNames(t *testing.T) {
+	assert.Equal(t, []string{"memory", "redis"}, env.ImplNames(reflect.TypeFor[TestStore]()))
+}
+
+func TestImplParse(t *testing.T) {
+	actual, err := env.LoadWith[TestImpl](env.Loader{Source: env.MapSource{
+		"STORE_TYPE": "Redis",
+		"STORE_ADDR": "localhost:6379",
+		"STORE_DB":   "2",
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, TestImpl{
+		Store: TestRedisStore{Addr: "localhost:6379", DB: 2},
+		Cache: &TestMemoryStore{},
+	}, actual)
+
+	vars, err := env.Marshal(actual)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"STORE_TYPE": "redis",
+		"STORE_ADDR": "localhost:6379",
+		"STORE_DB":   "2",
+		"CACHE_TYPE": "memory",
+		"CACHE_SIZE": "0",
+	}, vars)
+}
+
+func TestImplErrors(t *testing.T) {
+	_, err := env.LoadWith[TestImpl](env.Loader{Source: env.MapSource{}})
+	assert.EqualError(t, err, "STORE_TYPE: required")
+	assert.ErrorIs(t, err, env.ErrRequired)
+
+	_, err = env.LoadWith[TestImpl](env.Loader{Source: env.MapSource{"STORE_TYPE": "etcd"}})
+	assert.EqualError(t, err, `STORE_TYPE: invalid value "etcd", expected one of memory, redis`)
+
+	_, err = env.LoadWith[TestImpl](env.Loader{CaseInsensitive: true, Source: env.MapSource{
+		"store_type": "redis",
+		"Store_Type": "memory",
+	}})
+	assert.EqualError(t, err, "STORE_TYPE: STORE_TYPE is ambiguous, it matches Store_Type and store_type")
+	assert.ErrorIs(t, err, env.ErrAmbiguous)
+
+	_, err = env.LoadWith[TestImpl](env.Loader{Source: env.MapSource{"STORE_TYPE": "redis"}})
+	assert.EqualError(t, err, "STORE_ADDR: required")
+}
+
+type TestImplNotRequired struct {
+	Store TestStore `env:"STORE_" env-required:"false"`
+}
+
+func TestImplOptional(t *testing.T) {
+	actual, err := env.LoadWith[TestImplNotRequired](env.Loader{Source: env.MapSource{}})
+	assert.NoError(t, err)
+	assert.Nil(t, actual.Store)
+}
+
+type TestImplNamed struct {
+	Store TestStore
+}
+
+func TestImplNaming(t *testing.T) {
+	actual, err := env.LoadWith[TestImplNamed](env.Loader{Names: env.NameUpperSnake, Source: env.MapSource{
+		"STORE_TYPE": "redis",
+		"STORE_ADDR": "localhost:6379",
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, TestImplNamed{Store: TestRedisStore{Addr: "localhost:6379"}}, actual)
+}
@@ -0,0 +1,79 @@
+package env
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Options for loading values from the environment. The zero value is what
+// Parse, Load, and Get use.
+type Loader struct {
+	// The active profile which prefers env-default-<profile> struct tags over
+	// TagEnvDefault. When empty the ProfileVariable environment variable is used.
+	Profile string
+
+	// The source of environment variables, the process environment when nil.
+	Source Source
+	// The names of fields without a TagEnv struct tag, the field name when nil.
+	// With a strategy untagged nested structs prefix their fields, ex: DATABASE_HOST.
+	Names NameStrategy
+
+	// The prefix of all variables which aren't absolute, ex: MYAPP_.
+	Prefix string
+
+	// Looks up variables case-insensitively, preferring an exact match. A
+	// variable with more than one case-insensitive match is an AmbiguousError.
+	// The Source must be a NamesSource.
+	CaseInsensitive bool
+
+	// The flags bound with BindFlags, those which were set take precedence over the Source.
+	Flags *flag.FlagSet
+
+	// The variable names of the source by their lowercase name when CaseInsensitive.
+	folded map[string][]string
+}
+
+// Loads the type from environment variables with the given loader.
+func LoadWith[T any](loader Loader) (T, error) {
+	var parsed T
+	return parsed, loader.Parse(&parsed)
+}
+
+// Loads the value (expected to be pointer) from environment variables.
+func (l Loader) Parse(value any) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			if r, ok := recovered.(error); ok {
+				err = r
+			} else {
+				err = fmt.Errorf("%v", recovered)
+			}
+		}
+	}()
+
+	if l.Flags != nil {
+		l.Source = Layers{flagSource(l.Flags), l.rootState().Source()}
+	}
+	state := l.rootState()
+	if l.CaseInsensitive {
+		l.folded, err = foldNames(state.Source())
+		if err != nil {
+			return err
+		}
+	}
+	if l.Profile == "" && ProfileVariable != "" {
+		l.Profile, _ = state.lookup(ProfileVariable)
+	}
+	l.Profile = strings.ToLower(l.Profile)
+
+	rv := reflect.ValueOf(value)
+	parseError := parse(rv, state)
+	if parseError != nil && !errors.Is(parseError, ErrMissing) {
+		err = parseError
+	}
+
+	return err
+}
@@ -0,0 +1,333 @@
+package env
+
+import (
+	"encoding"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// A marshaller of a value into its environment value given the current state.
+type Marshaller interface {
+	MarshalEnv(state UnmarshalState) (string, error)
+}
+
+// A custom formatter for a given type, the inverse of a Parser.
+type Formatter func(value any, state UnmarshalState) (string, error)
+
+// The struct tag which marks a field as a secret, ex: `env-secret:"true"`.
+var TagEnvSecret = "env-secret"
+
+// Registers a custom formatter for the given type.
+func RegisterFormatter[T any](formatter Formatter) {
+	key := reflect.TypeFor[T]()
+	formatters[key] = formatter
+}
+
+// Options for writing a value out to environment variables.
+type EnvironOptions struct {
+	// Skips fields marked with the TagEnvSecret struct tag.
+	SkipSecrets bool
+	// Environ returns only the variables for the value instead of merging them
+	// with os.Environ. This doesn't affect Apply, which never removes variables.
+	Replace bool
+	// The naming and prefix of the variables, as they would be loaded.
+	Loader Loader
+}
+
+// Returns the environment variables for the value keyed by their name. The
+// first name of each field is used, with the same prefix rules as Parse.
+func Marshal(value any) (map[string]string, error) {
+	return EnvironOptions{}.Marshal(value)
+}
+
+// Returns the environment of the process merged with the variables for the
+// value, in the "key=value" form used by os.Environ and exec.Cmd.
+func Environ(value any) ([]string, error) {
+	return EnvironOptions{}.Environ(value)
+}
+
+// Sets the environment variables of the process to the variables for the value.
+func Apply(value any) error {
+	return EnvironOptions{}.Apply(value)
+}
+
+// Returns the environment variables for the value keyed by their name.
+func (o EnvironOptions) Marshal(value any) (map[string]string, error) {
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() {
+		return nil, fmt.Errorf("cannot marshal %v", value)
+	}
+	if rv.Kind() != reflect.Pointer {
+		addressable := reflect.New(rv.Type())
+		addressable.Elem().Set(rv)
+		rv = addressable
+	}
+	out := make(map[string]string)
+	err := o.format(rv, o.Loader.rootState(), out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Returns the variables for the value in the "key=value" form used by
+// os.Environ and exec.Cmd, merged with os.Environ unless Replace is true.
+func (o EnvironOptions) Environ(value any) ([]string, error) {
+	vars, err := o.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var environ []string
+	if !o.Replace {
+		for _, pair := range os.Environ() {
+			name, _, _ := strings.Cut(pair, "=")
+			if _, overwritten := vars[name]; !overwritten {
+				environ = append(environ, pair)
+			}
+		}
+	}
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		environ = append(environ, name+"="+vars[name])
+	}
+	return environ, nil
+}
+
+// Sets the variables for the value on the process. Other variables are kept.
+func (o EnvironOptions) Apply(value any) error {
+	vars, err := o.Marshal(value)
+	if err != nil {
+		return err
+	}
+	for name, text := range vars {
+		if err := os.Setenv(name, text); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Formats the value into out under the first variable of the state.
+func (o EnvironOptions) format(rv reflect.Value, state UnmarshalState, out map[string]string) error {
+	text, exists, err := o.formatText(rv, state, out)
+	if err != nil || !exists {
+		return err
+	}
+	if len(state.Variables) == 0 || state.Variables[0] == "" {
+		return fmt.Errorf("no variable name for type %v", rv.Type())
+	}
+	out[state.Variables[0]] = text
+	return nil
+}
+
+// Formats the value into text, returns false when there is no value to write.
+// Structs have their fields written to out directly.
+func (o EnvironOptions) formatText(rv reflect.Value, state UnmarshalState, out map[string]string) (string, bool, error) {
+	if null, hasNull := state.Null(); hasNull && isNilable(rv) && rv.IsNil() {
+		return null, true, nil
+	}
+
+	if name, hasFormat := state.Format(); hasFormat {
+		if (rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface || rv.Kind() == reflect.Map || rv.Kind() == reflect.Slice) && rv.IsNil() {
+			return "", false, nil
+		}
+		text, err := formatFormat(rv, name)
+		return text, err == nil, err
+	}
+
+	// Pointers are formatted through their element unless the pointer type has a formatter.
+	if _, hasFormatter := formatters[rv.Type()]; rv.Kind() == reflect.Pointer && !hasFormatter {
+		if rv.IsNil() {
+			return "", false, nil
+		}
+		return o.formatText(rv.Elem(), state, out)
+	}
+	if rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return "", false, nil
+	}
+
+	if optional, ok := asInterface[optionalValue](rv); ok {
+		value, set, empty := optional.optionalValue()
+		if empty {
+			return "", true, nil
+		}
+		if !set || !value.IsValid() {
+			return "", false, nil
+		}
+		return o.formatText(value, state, out)
+	}
+
+	if marshaller, ok := asInterface[Marshaller](rv); ok {
+		text, err := marshaller.MarshalEnv(state)
+		return text, err == nil, err
+	}
+
+	if formatter, ok := formatters[rv.Type()]; ok {
+		text, err := formatter(rv.Interface(), state)
+		if err != nil {
+			return "", false, fmt.Errorf("error in custom formatter for type %v: %w", rv.Type(), err)
+		}
+		return text, true, nil
+	}
+
+	if marshaller, ok := asInterface[encoding.TextMarshaler](rv); ok {
+		text, err := marshaller.MarshalText()
+		return string(text), err == nil, err
+	}
+
+	switch rv.Kind() {
+	case reflect.Array, reflect.Slice:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return "", false, nil
+		}
+		if isBytes(rv.Type()) {
+			text, err := state.EncodeBytes(bytesOf(rv))
+			return text, err == nil, err
+		}
+		elements := make([]string, rv.Len())
+		for i := range rv.Len() {
+			text, err := o.formatElement(rv.Index(i), state)
+			if err != nil {
+				return "", false, fmt.Errorf("at index %d: %w", i, err)
+			}
+			elements[i] = text
+		}
+		text, err := state.JoinElements(elements)
+		return text, err == nil, err
+	case reflect.Map:
+		if rv.IsNil() {
+			return "", false, nil
+		}
+		text, err := o.formatMap(rv, state)
+		return text, err == nil, err
+	case reflect.Struct:
+		if out == nil {
+			return "", false, fmt.Errorf("cannot format struct %v as a single value", rv.Type())
+		}
+		for i := range rv.NumField() {
+			fieldStruct := rv.Type().Field(i)
+			if !fieldStruct.IsExported() && !fieldStruct.Anonymous {
+				continue
+			}
+			fieldState, skip := newFieldState(fieldStruct, state)
+			if skip {
+				continue
+			}
+			if o.SkipSecrets {
+				secret, err := fieldState.Secret()
+				if err != nil {
+					return "", false, fmt.Errorf("parsing %s of %s: %w", TagEnvSecret, fieldState, err)
+				}
+				if secret {
+					continue
+				}
+			}
+			err := o.format(rv.Field(i), fieldState, out)
+			if err != nil {
+				return "", false, FieldError{Variables: fieldState.Variables, Err: err}
+			}
+		}
+		return "", false, nil
+	case reflect.Interface:
+		if out == nil {
+			return "", false, fmt.Errorf("cannot format interface %v as a single value", rv.Type())
+		}
+		return "", false, o.formatImpl(rv, state, out)
+	case reflect.String:
+		return rv.String(), true, nil
+	case reflect.Bool:
+		// A missing variable is false when presence means true.
+		if presence, _ := state.Presence(); presence && !rv.Bool() {
+			return "", false, nil
+		}
+		return state.FormatBool(rv.Bool()), true, nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, kindBits[rv.Kind()]), true, nil
+	case reflect.Complex64, reflect.Complex128:
+		return strconv.FormatComplex(rv.Complex(), 'g', -1, kindBits[rv.Kind()]), true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		unit, err := state.Unit()
+		if err != nil {
+			return "", false, err
+		}
+		if unit == UnitBytes && rv.Int() >= 0 {
+			return FormatBytes(uint64(rv.Int())), true, nil
+		}
+		base, err := state.formatBase()
+		if err != nil {
+			return "", false, err
+		}
+		return strconv.FormatInt(rv.Int(), base), true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		unit, err := state.Unit()
+		if err != nil {
+			return "", false, err
+		}
+		if unit == UnitBytes {
+			return FormatBytes(rv.Uint()), true, nil
+		}
+		base, err := state.formatBase()
+		if err != nil {
+			return "", false, err
+		}
+		return strconv.FormatUint(rv.Uint(), base), true, nil
+	}
+
+	return "", false, fmt.Errorf("kind %s not supported", rv.Kind())
+}
+
+// Returns the value as the interface, checking the pointer to the value as well.
+func asInterface[I any](rv reflect.Value) (I, bool) {
+	if rv.CanInterface() {
+		if i, ok := rv.Interface().(I); ok {
+			return i, true
+		}
+	}
+	if rv.CanAddr() && rv.Addr().CanInterface() {
+		if i, ok := rv.Addr().Interface().(I); ok {
+			return i, true
+		}
+	}
+	var none I
+	return none, false
+}
+
+// Returns whether the TagEnvSecret struct tag marks this value as a secret.
+func (us UnmarshalState) Secret() (bool, error) {
+	secretText, exists := us.Tag(TagEnvSecret, "")
+	if !exists {
+		return false, nil
+	}
+	return strconv.ParseBool(secretText)
+}
+
+// Returns the base to format integers with, base 0 is formatted as base 10.
+func (us UnmarshalState) formatBase() (int, error) {
+	base, err := us.Base()
+	if base == 0 {
+		base = 10
+	}
+	return base, err
+}
+
+// Returns the literal delimiter used to join array/slice values based on
+// the env.TagEnvDelim struct tag and env.DefaultDelimiter.
+func (us UnmarshalState) JoinDelim() (string, error) {
+	delim, err := us.Delim()
+	if err != nil {
+		return "", err
+	}
+	literal, complete := delim.LiteralPrefix()
+	if !complete {
+		return "", fmt.Errorf("cannot join with %s pattern %q", TagEnvDelim, delim)
+	}
+	return literal, nil
+}
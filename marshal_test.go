@@ -0,0 +1,145 @@
+package env_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/clickermonkey/env"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestMarshalConnection struct {
+	User string `env:"USER,USERNAME"`
+	Pass string `env:"PASS" env-secret:"true"`
+}
+
+type TestMarshalled struct {
+	ComplexEmbedded
+
+	Name     string                  `env:"TMA_NAME"`
+	Timeout  time.Duration           `env:"TMA_TIMEOUT"`
+	Ports    []uint16                `env:"TMA_PORTS" env-delim:"\\|"`
+	Ratio    float32                 `env:"TMA_RATIO"`
+	Enabled  *bool                   `env:"TMA_ENABLED"`
+	Missing  *int                    `env:"TMA_MISSING"`
+	Database TestMarshalConnection   `env:"TMA_DB_,TMA_DATABASE_"`
+	Skipped  string                  `env:"-"`
+	Text     TestTextMarshaller      `env:"TMA_TEXT"`
+	Custom   TestMarshalUnmarshaller `env:"TMA_CUSTOM"`
+	Mode     os.FileMode             `env:"TMA_MODE"`
+	Mask     uint16                  `env:"TMA_MASK" env-base:"16"`
+	Enum     TestMode                `env:"TMA_ENUM"`
+}
+
+type TestTextMarshaller struct {
+	value string
+}
+
+func (tm TestTextMarshaller) MarshalText() ([]byte, error) {
+	return []byte("text:" + tm.value), nil
+}
+
+type TestMarshalUnmarshaller struct {
+	value string
+}
+
+func (tm *TestMarshalUnmarshaller) MarshalEnv(state env.UnmarshalState) (string, error) {
+	return "custom:" + tm.value, nil
+}
+
+func newTestMarshal() TestMarshalled {
+	enabled := true
+	return TestMarshalled{
+		ComplexEmbedded: ComplexEmbedded{EmbeddedString: "embedded"},
+		Name:            "name",
+		Timeout:         90 * time.Second,
+		Ports:           []uint16{80, 443},
+		Ratio:           0.5,
+		Enabled:         &enabled,
+		Database:        TestMarshalConnection{User: "u", Pass: "p"},
+		Skipped:         "skipped",
+		Text:            TestTextMarshaller{value: "t"},
+		Custom:          TestMarshalUnmarshaller{value: "c"},
+		Mode:            0640,
+		Mask:            0xbeef,
+		Enum:            TestModeWrite,
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	vars, err := env.Marshal(newTestMarshal())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"CN_EMBEDDED_STRING": "embedded",
+		"TMA_NAME":           "name",
+		"TMA_TIMEOUT":        "1m30s",
+		"TMA_PORTS":          "80|443",
+		"TMA_RATIO":          "0.5",
+		"TMA_ENABLED":        "true",
+		"TMA_DB_USER":        "u",
+		"TMA_DB_PASS":        "p",
+		"TMA_TEXT":           "text:t",
+		"TMA_CUSTOM":         "custom:c",
+		"TMA_MODE":           "0640",
+		"TMA_MASK":           "beef",
+		"TMA_ENUM":           "write",
+	}, vars)
+}
+
+func TestEnviron(t *testing.T) {
+	os.Setenv("TMA_NAME", "existing")
+	os.Setenv("TMA_EXISTING", "existing")
+	defer os.Unsetenv("TMA_NAME")
+	defer os.Unsetenv("TMA_EXISTING")
+
+	merged, err := env.Environ(newTestMarshal())
+	assert.NoError(t, err)
+	assert.Contains(t, merged, "TMA_EXISTING=existing")
+	assert.Contains(t, merged, "TMA_NAME=name")
+	assert.NotContains(t, merged, "TMA_NAME=existing")
+
+	replaced, err := env.EnvironOptions{Replace: true, SkipSecrets: true}.Environ(newTestMarshal())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"CN_EMBEDDED_STRING=embedded",
+		"TMA_CUSTOM=custom:c",
+		"TMA_DB_USER=u",
+		"TMA_ENABLED=true",
+		"TMA_ENUM=write",
+		"TMA_MASK=beef",
+		"TMA_MODE=0640",
+		"TMA_NAME=name",
+		"TMA_PORTS=80|443",
+		"TMA_RATIO=0.5",
+		"TMA_TEXT=text:t",
+		"TMA_TIMEOUT=1m30s",
+	}, replaced)
+}
+
+func TestApply(t *testing.T) {
+	expected := SimpleWithTags{
+		Text:     "abc",
+		Times:    3,
+		Duration: 2 * time.Minute,
+		Check:    true,
+	}
+	defer func() {
+		for _, name := range []string{"SIMPLE_WT_TEXT", "SIMPLE_WT_TIMES", "SIMPLE_WT_DURATION", "SIMPLE_WT_CHECK"} {
+			os.Unsetenv(name)
+		}
+	}()
+
+	assert.NoError(t, env.Apply(expected))
+
+	actual, err := env.Load[SimpleWithTags]()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	os.Setenv("TMA_KEPT", "kept")
+	defer os.Unsetenv("TMA_KEPT")
+	assert.NoError(t, env.EnvironOptions{Replace: true}.Apply(expected))
+	kept, exists := os.LookupEnv("TMA_KEPT")
+	assert.True(t, exists)
+	assert.Equal(t, "kept", kept)
+}
@@ -0,0 +1,92 @@
+package env
+
+import (
+	"encoding"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// Converts a Go field name into its environment variable name, used for
+// fields without a TagEnv struct tag.
+type NameStrategy func(fieldName string) string
+
+// The separator added after the name of an untagged nested struct or interface
+// when a NameStrategy is used, ex: field Database becomes the prefix DATABASE_.
+var NestedSeparator = "_"
+
+// Uses the field name as is, ex: TokenLifetime.
+func NameVerbatim(fieldName string) string {
+	return fieldName
+}
+
+// Uses the field name in upper snake case, ex: TokenLifetime is TOKEN_LIFETIME
+// and HTTPServer is HTTP_SERVER.
+func NameUpperSnake(fieldName string) string {
+	runes := []rune(fieldName)
+	var name strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			previous := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextLower) {
+				name.WriteRune('_')
+			}
+		}
+		name.WriteRune(unicode.ToUpper(r))
+	}
+	return name.String()
+}
+
+// Returns the state at the root of a value, with the loader's Prefix as the variable.
+func (l *Loader) rootState() UnmarshalState {
+	state := UnmarshalState{loader: l}
+	if l.Prefix != "" {
+		state.Variables = []string{l.Prefix}
+	}
+	return state
+}
+
+// Returns the variable name of a field without a TagEnv struct tag. Embedded
+// fields have no name. With a NameStrategy untagged nested structs and
+// interfaces are prefixes.
+func (us UnmarshalState) defaultName(field reflect.StructField) string {
+	if field.Anonymous {
+		return ""
+	}
+	if us.loader == nil || us.loader.Names == nil {
+		return field.Name
+	}
+	name := us.loader.Names(field.Name)
+	if us.parsesFields(field.Type) || us.parsesImpl(field.Type) {
+		name += NestedSeparator
+	}
+	return name
+}
+
+// Returns whether the field of the given type is parsed as fields under its
+// variables, which a struct with a TagEnvFormat struct tag is not.
+func (us UnmarshalState) parsesFields(t reflect.Type) bool {
+	if _, hasFormat := us.Format(); hasFormat {
+		return false
+	}
+	return hasFields(t)
+}
+
+// Returns whether the type is parsed as fields rather than a single value.
+func hasFields(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		if _, hasParser := parserFor(t); hasParser {
+			return false
+		}
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	if _, hasParser := parserFor(t); hasParser {
+		return false
+	}
+	pointer := reflect.PointerTo(t)
+	return !pointer.Implements(reflect.TypeFor[Unmarshaller]()) && !pointer.Implements(reflect.TypeFor[encoding.TextUnmarshaler]())
+}
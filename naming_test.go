@@ -0,0 +1,92 @@
+package env_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/clickermonkey/env"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestNamingDatabase struct {
+	Host string
+	Port int
+}
+
+type TestNamingConfig struct {
+	TokenLifetime time.Duration
+	HTTPServer    string
+	Database      TestNamingDatabase
+	Replica       *TestNamingDatabase
+	Shared        string `env:"^SHARED"`
+	Tagged        string `env:"TAG"`
+}
+
+func TestNameUpperSnake(t *testing.T) {
+	assert.Equal(t, "TOKEN_LIFETIME", env.NameUpperSnake("TokenLifetime"))
+	assert.Equal(t, "HTTP_SERVER", env.NameUpperSnake("HTTPServer"))
+	assert.Equal(t, "API_KEY", env.NameUpperSnake("APIKey"))
+	assert.Equal(t, "REDIS2_HOST", env.NameUpperSnake("Redis2Host"))
+	assert.Equal(t, "ID", env.NameUpperSnake("ID"))
+	assert.Equal(t, "X", env.NameUpperSnake("x"))
+}
+
+func TestNaming(t *testing.T) {
+	loader := env.Loader{
+		Names:  env.NameUpperSnake,
+		Prefix: "MYAPP_",
+		Source: env.MapSource{
+			"MYAPP_TOKEN_LIFETIME": "1h0m0s",
+			"MYAPP_HTTP_SERVER":    "web",
+			"MYAPP_DATABASE_HOST":  "db",
+			"MYAPP_DATABASE_PORT":  "5432",
+			"MYAPP_REPLICA_HOST":   "replica",
+			"MYAPP_REPLICA_PORT":   "5433",
+			"SHARED":               "shared",
+			"MYAPP_TAG":            "tag",
+		},
+	}
+	actual, err := env.LoadWith[TestNamingConfig](loader)
+	assert.NoError(t, err)
+	expected := TestNamingConfig{
+		TokenLifetime: time.Hour,
+		HTTPServer:    "web",
+		Database:      TestNamingDatabase{Host: "db", Port: 5432},
+		Replica:       &TestNamingDatabase{Host: "replica", Port: 5433},
+		Shared:        "shared",
+		Tagged:        "tag",
+	}
+	assert.Equal(t, expected, actual)
+
+	vars, err := env.EnvironOptions{Loader: loader}.Marshal(actual)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string(loader.Source.(env.MapSource)), vars)
+}
+
+func TestNamingCustom(t *testing.T) {
+	actual, err := env.LoadWith[TestNamingDatabase](env.Loader{
+		Names:  func(fieldName string) string { return "db." + fieldName },
+		Source: env.MapSource{"db.Host": "db", "db.Port": "1"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, TestNamingDatabase{Host: "db", Port: 1}, actual)
+}
+
+type TestNamingFormatted struct {
+	Config TestNamingDatabase `env-format:"json" env-group:"config,exactly-one"`
+	Legacy string             `env-group:"config"`
+}
+
+func TestNamingFormat(t *testing.T) {
+	loader := env.Loader{
+		Names:  env.NameUpperSnake,
+		Source: env.MapSource{"CONFIG": `{"Host":"db","Port":1}`},
+	}
+	actual, err := env.LoadWith[TestNamingFormatted](loader)
+	assert.NoError(t, err)
+	assert.Equal(t, TestNamingFormatted{Config: TestNamingDatabase{Host: "db", Port: 1}}, actual)
+
+	vars, err := env.EnvironOptions{Loader: loader}.Marshal(actual)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"CONFIG": `{"Host":"db","Port":1}`, "LEGACY": ""}, vars)
+}
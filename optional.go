@@ -0,0 +1,116 @@
+package env
+
+import (
+	"reflect"
+	"slices"
+)
+
+// An optional value which records whether it was set, whether it was set but
+// empty, and whether it came from a variable or the default. Optional fields
+// are not required by default.
+type Optional[T any] struct {
+	value       T
+	set         bool
+	empty       bool
+	fromEnv     bool
+	fromDefault bool
+}
+
+// Returns an Optional which is set to the value, from neither a variable nor a default.
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{value: value, set: true}
+}
+
+// Returns whether the value was set by a variable or a default.
+func (o Optional[T]) IsSet() bool {
+	return o.set
+}
+
+// Returns the value and whether it was set.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.set
+}
+
+// Returns the value if it was set, otherwise the fallback.
+func (o Optional[T]) OrElse(fallback T) T {
+	if !o.set {
+		return fallback
+	}
+	return o.value
+}
+
+// Returns whether the value was set but empty, which is not parsed and leaves
+// the value as the zero value, ex: PORT= for an Optional[int].
+func (o Optional[T]) IsEmpty() bool {
+	return o.empty
+}
+
+// Returns whether the value came from a variable.
+func (o Optional[T]) FromEnv() bool {
+	return o.fromEnv
+}
+
+// Returns whether the value came from the default.
+func (o Optional[T]) FromDefault() bool {
+	return o.fromDefault
+}
+
+// An Optional of any type.
+type optionalValue interface {
+	optionalValue() (value reflect.Value, set bool, empty bool)
+}
+
+func (o Optional[T]) optionalValue() (reflect.Value, bool, bool) {
+	return reflect.ValueOf(o.value), o.set, o.empty
+}
+
+// A pointer to an Optional of any type.
+type optionalDecoder interface {
+	decodeOptional(state UnmarshalState) error
+}
+
+func (o *Optional[T]) decodeOptional(state UnmarshalState) error {
+	fromDefault := state.readsDefault()
+	// An empty value is its own state rather than a value to parse, except for presence flags.
+	text, exists := state.Read()
+	if presence, _ := state.Presence(); exists && text == "" && !presence {
+		*o = Optional[T]{set: true, empty: true, fromEnv: !fromDefault, fromDefault: fromDefault}
+		return nil
+	}
+	var value T
+	if err := state.Decode(&value); err != nil {
+		return err
+	}
+	*o = Optional[T]{value: value, set: true, fromEnv: !fromDefault, fromDefault: fromDefault}
+	return nil
+}
+
+// Registers the parser for every Optional.
+func registerOptional() {
+	RegisterParserWhen(SameGeneric[Optional[any]](), func(t reflect.Type, state UnmarshalState) (any, error) {
+		optional := reflect.New(t)
+		if err := optional.Interface().(optionalDecoder).decodeOptional(state); err != nil {
+			return nil, err
+		}
+		return optional.Elem().Interface(), nil
+	})
+}
+
+// Returns whether the value of this state comes from its default rather than a variable.
+func (us UnmarshalState) readsDefault() bool {
+	if us.read != nil {
+		return false
+	}
+	_, found := us.foundVariable()
+	return !found
+}
+
+// Returns the first variable of this state which has a value, ignoring defaults.
+func (us UnmarshalState) foundVariable() (string, bool) {
+	for _, varName := range append(slices.Clip(us.Variables), us.Deprecated...) {
+		if _, exists := us.lookupValue(varName); exists {
+			return varName, true
+		}
+	}
+	return "", false
+}
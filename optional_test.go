@@ -0,0 +1,89 @@
+package env_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/clickermonkey/env"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestOptional struct {
+	Port    env.Optional[int]           `env:"PORT"`
+	Host    env.Optional[string]        `env:"HOST" env-default:"localhost"`
+	Name    env.Optional[string]        `env:"NAME"`
+	Timeout env.Optional[time.Duration] `env:"TIMEOUT"`
+	Tags    env.Optional[[]string]      `env:"TAGS"`
+	Secure  env.Optional[bool]          `env:"SECURE" env-required:"true"`
+}
+
+func TestOptionalValues(t *testing.T) {
+	actual, err := env.LoadWith[TestOptional](env.Loader{Source: env.MapSource{
+		"PORT":    "",
+		"NAME":    "",
+		"TIMEOUT": "5s",
+		"TAGS":    "a,b",
+		"SECURE":  "true",
+	}})
+	assert.NoError(t, err)
+
+	assert.True(t, actual.Port.IsEmpty())
+	assert.True(t, actual.Port.FromEnv())
+	assert.Equal(t, 0, actual.Port.OrElse(8080))
+
+	host, set := actual.Host.Get()
+	assert.True(t, set)
+	assert.Equal(t, "localhost", host)
+	assert.True(t, actual.Host.FromDefault())
+	assert.False(t, actual.Host.FromEnv())
+
+	name, set := actual.Name.Get()
+	assert.True(t, set)
+	assert.Equal(t, "", name)
+	assert.True(t, actual.Name.FromEnv())
+	assert.True(t, actual.Name.IsEmpty())
+	assert.False(t, actual.Timeout.IsEmpty())
+
+	assert.Equal(t, 5*time.Second, actual.Timeout.OrElse(0))
+	assert.Equal(t, []string{"a", "b"}, actual.Tags.OrElse(nil))
+
+	vars, err := env.Marshal(actual)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"PORT":    "",
+		"HOST":    "localhost",
+		"NAME":    "",
+		"TIMEOUT": "5s",
+		"TAGS":    "a,b",
+		"SECURE":  "true",
+	}, vars)
+}
+
+func TestOptionalUnset(t *testing.T) {
+	actual, err := env.LoadWith[TestOptional](env.Loader{Source: env.MapSource{"SECURE": "true"}})
+	assert.NoError(t, err)
+	assert.False(t, actual.Port.IsSet())
+	assert.False(t, actual.Port.IsEmpty())
+	assert.Equal(t, 8080, actual.Port.OrElse(8080))
+	_, set := actual.Port.Get()
+	assert.False(t, set)
+}
+
+func TestOptionalErrors(t *testing.T) {
+	_, err := env.LoadWith[TestOptional](env.Loader{Source: env.MapSource{}})
+	assert.EqualError(t, err, "SECURE: required")
+
+	_, err = env.LoadWith[TestOptional](env.Loader{Source: env.MapSource{"PORT": "http", "SECURE": "true"}})
+	var fieldErr env.FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, []string{"PORT"}, fieldErr.Variables)
+}
+
+func TestOptionalSome(t *testing.T) {
+	some := env.Some(3)
+	assert.True(t, some.IsSet())
+	assert.Equal(t, 3, some.OrElse(0))
+	assert.False(t, some.FromDefault())
+	assert.False(t, some.FromEnv())
+}
@@ -0,0 +1,87 @@
+package env
+
+import (
+	"strconv"
+	"strings"
+)
+
+// The environment variable which selects the active profile when the
+// Loader doesn't specify one. Set to empty to disable.
+var ProfileVariable = "APP_PROFILE"
+
+// Returns the active profile, lowercased, or an empty string if there is none.
+func (us UnmarshalState) Profile() string {
+	if us.loader == nil {
+		return ""
+	}
+	return us.loader.Profile
+}
+
+// Returns every default specified on the struct tag keyed by profile. The
+// TagEnvDefault value is keyed by an empty string.
+func (us UnmarshalState) Defaults() map[string]string {
+	if us.Field == nil {
+		return nil
+	}
+	var defaults map[string]string
+	profilePrefix := TagEnvDefault + "-"
+	for _, key := range tagKeys(string(us.Field.Tag)) {
+		profile := ""
+		if key != TagEnvDefault {
+			if !strings.HasPrefix(key, profilePrefix) {
+				continue
+			}
+			profile = strings.TrimPrefix(key, profilePrefix)
+		}
+		if defaults == nil {
+			defaults = make(map[string]string)
+		}
+		defaults[profile], _ = us.Field.Tag.Lookup(key)
+	}
+	return defaults
+}
+
+// Returns the keys in a conventionally formatted struct tag, in order.
+func tagKeys(tag string) []string {
+	var keys []string
+	for tag != "" {
+		// Skip leading space.
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		tag = tag[i:]
+		if tag == "" {
+			break
+		}
+
+		// Scan to colon.
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		key := tag[:i]
+		tag = tag[i+1:]
+
+		// Scan quoted string to find value.
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		if _, err := strconv.Unquote(tag[:i+1]); err != nil {
+			break
+		}
+		tag = tag[i+1:]
+		keys = append(keys, key)
+	}
+	return keys
+}
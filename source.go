@@ -0,0 +1,110 @@
+package env
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// A source of environment variables.
+type Source interface {
+	LookupEnv(name string) (string, bool)
+}
+
+// The environment of the current process.
+type ProcessSource struct{}
+
+var _ Source = ProcessSource{}
+
+func (ProcessSource) LookupEnv(name string) (string, bool) {
+	return os.LookupEnv(name)
+}
+
+// An in-memory source of environment variables.
+type MapSource map[string]string
+
+var _ Source = MapSource{}
+
+func (ms MapSource) LookupEnv(name string) (string, bool) {
+	value, exists := ms[name]
+	return value, exists
+}
+
+// Reads variables in the .env format. Each line is a NAME=value pair which
+// may start with "export ". Blank lines and lines starting with # are ignored,
+// values may be single quoted (literal) or double quoted (with escapes).
+func ReadDotEnv(r io.Reader) (MapSource, error) {
+	vars := make(MapSource)
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		name, value, found := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("line %d: expected NAME=value", lineNumber)
+		}
+		value, err := readValue(value, " #")
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		vars[name] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return vars, nil
+}
+
+// Reads a value which may be single quoted (literal), double quoted (with
+// escapes), or unquoted with an optional inline comment.
+func readValue(value string, comments ...string) (string, error) {
+	value = strings.TrimSpace(value)
+	switch {
+	case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+		return strconv.Unquote(value)
+	case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+		return value[1 : len(value)-1], nil
+	}
+	for _, comment := range comments {
+		if index := strings.Index(value, comment); index != -1 {
+			value = strings.TrimSpace(value[:index])
+		}
+	}
+	return value, nil
+}
+
+// Sources layered by precedence, the first source with a variable is used.
+type Layers []Source
+
+var _ NamesSource = Layers{}
+
+func (ls Layers) LookupEnv(name string) (string, bool) {
+	for _, source := range ls {
+		if value, exists := source.LookupEnv(name); exists {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// Returns the names of all layers, a layer without names has none.
+func (ls Layers) Names() []string {
+	var names []string
+	for _, source := range ls {
+		if namesSource, ok := source.(NamesSource); ok {
+			names = append(names, namesSource.Names()...)
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
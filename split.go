@@ -0,0 +1,271 @@
+package env
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// The struct tag which defines the delimiters of nested slice/array/map values,
+	// one character per level from the outermost, ex: `env-delims:";,"` for [][]string.
+	// Elements can be double quoted (with "" for a quote) and characters can be
+	// escaped with a backslash. This takes precedence over TagEnvDelim.
+	TagEnvDelims = "env-delims"
+
+	// The delimiter between a key and value of a map entry, ex: a=1,b=2.
+	KeyValueDelimiter = "="
+)
+
+// Returns the delimiters for each level in the TagEnvDelims struct tag, if any.
+func (us UnmarshalState) Delims() []rune {
+	delims, exists := us.Tag(TagEnvDelims, "")
+	if !exists || delims == "" {
+		return nil
+	}
+	return []rune(delims)
+}
+
+// Returns whether elements are quoted & escaped, which is when the TagEnvDelims struct tag is given.
+func (us UnmarshalState) Quoted() bool {
+	return us.Delims() != nil
+}
+
+// Splits the text into elements for the current level of nesting, at most
+// times elements when times is not negative. With the TagEnvDelims struct tag
+// quotes and escapes are respected and left in the elements, otherwise the
+// text is split with Delim.
+func (us UnmarshalState) SplitElements(text string, times int) ([]string, error) {
+	delims := us.Delims()
+	if delims == nil {
+		return us.Split(text, times)
+	}
+	if us.level >= len(delims) {
+		return nil, fmt.Errorf("%s has %d levels but the value is nested %d levels deep", TagEnvDelims, len(delims), us.level+1)
+	}
+	return splitQuoted(text, delims[us.level], times)
+}
+
+// Joins the elements for the current level of nesting.
+func (us UnmarshalState) JoinElements(elements []string) (string, error) {
+	delims := us.Delims()
+	if delims == nil {
+		delim, err := us.JoinDelim()
+		if err != nil {
+			return "", err
+		}
+		return strings.Join(elements, delim), nil
+	}
+	if us.level >= len(delims) {
+		return "", fmt.Errorf("%s has %d levels but the value is nested %d levels deep", TagEnvDelims, len(delims), us.level+1)
+	}
+	return strings.Join(elements, string(delims[us.level])), nil
+}
+
+// Returns the state for an element of the given type read from text. A value
+// which doesn't consume a level of delimiters has its quotes and escapes removed.
+func (us UnmarshalState) element(text string, elementType reflect.Type) (UnmarshalState, error) {
+	elementState := us
+	elementState.level++
+	if us.Quoted() && !consumesLevel(elementType) {
+		unquoted, err := unquote(text)
+		if err != nil {
+			return elementState, err
+		}
+		text = unquoted
+	}
+	elementState.read = &text
+	elementState.readExists = true
+	return elementState, nil
+}
+
+// Returns whether the type is split into elements with a level of delimiters.
+func consumesLevel(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		if _, hasParser := parserFor(t); hasParser {
+			return false
+		}
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+	default:
+		return false
+	}
+	if _, hasParser := parserFor(t); hasParser {
+		return false
+	}
+	pointer := reflect.PointerTo(t)
+	if pointer.Implements(reflect.TypeFor[Unmarshaller]()) || pointer.Implements(reflect.TypeFor[encoding.TextUnmarshaler]()) {
+		return false
+	}
+	return !isBytes(t)
+}
+
+// Splits the text on the delimiter outside of quotes and escapes, which are
+// left in the elements.
+func splitQuoted(text string, delim rune, times int) ([]string, error) {
+	if times == 0 {
+		return nil, nil
+	}
+	var elements []string
+	quoted := false
+	start := 0
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case r == '\\':
+			_, escapedSize := utf8.DecodeRuneInString(text[i+size:])
+			size += escapedSize
+		case r == '"':
+			quoted = !quoted
+		case r == delim && !quoted && (times < 0 || len(elements) < times-1):
+			elements = append(elements, text[start:i])
+			start = i + size
+		}
+		i += size
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote in %q", text)
+	}
+	return append(elements, text[start:]), nil
+}
+
+// Removes the quotes and escapes from an element. Within quotes "" is a quote.
+func unquote(text string) (string, error) {
+	if !strings.ContainsAny(text, `"\`) {
+		return text, nil
+	}
+	var unquoted strings.Builder
+	quoted := false
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+		switch {
+		case r == '\\':
+			if i >= len(text) {
+				return "", fmt.Errorf("trailing escape in %q", text)
+			}
+			escaped, escapedSize := utf8.DecodeRuneInString(text[i:])
+			unquoted.WriteRune(escaped)
+			i += escapedSize
+		case r == '"' && quoted && strings.HasPrefix(text[i:], `"`):
+			unquoted.WriteRune('"')
+			i++
+		case r == '"':
+			quoted = !quoted
+		default:
+			unquoted.WriteRune(r)
+		}
+	}
+	if quoted {
+		return "", fmt.Errorf("unterminated quote in %q", text)
+	}
+	return unquoted.String(), nil
+}
+
+// Escapes the quotes, backslashes, delimiters, and key value delimiter in an element.
+func (us UnmarshalState) escape(text string) string {
+	special := `"\` + string(us.Delims()) + KeyValueDelimiter
+	if !strings.ContainsAny(text, special) {
+		return text
+	}
+	var escaped strings.Builder
+	for _, r := range text {
+		if strings.ContainsRune(special, r) {
+			escaped.WriteRune('\\')
+		}
+		escaped.WriteRune(r)
+	}
+	return escaped.String()
+}
+
+// Parses a map from the environment, splitting the value into key value entries.
+func parseMap(rv reflect.Value, state UnmarshalState) error {
+	text, exists := state.Read()
+	if !exists {
+		return ErrMissing
+	}
+	if text == "" {
+		return nil
+	}
+	entries, err := state.SplitElements(text, -1)
+	if err != nil {
+		return fmt.Errorf("error splitting: %w", err)
+	}
+	parsed := reflect.MakeMapWithSize(rv.Type(), len(entries))
+	for _, entry := range entries {
+		keyText, valueText, found := cutKeyValue(entry, state.Quoted())
+		if !found {
+			return fmt.Errorf("expected key%svalue but got %q", KeyValueDelimiter, entry)
+		}
+		key := reflect.New(rv.Type().Key()).Elem()
+		keyState, err := state.element(keyText, key.Type())
+		if err != nil {
+			return fmt.Errorf("at key %q: %w", keyText, err)
+		}
+		if err := parse(key, keyState); err != nil {
+			return fmt.Errorf("at key %q: %w", keyText, err)
+		}
+		value := reflect.New(rv.Type().Elem()).Elem()
+		valueState, err := state.element(valueText, value.Type())
+		if err != nil {
+			return fmt.Errorf("at key %q: %w", keyText, err)
+		}
+		if err := parse(value, valueState); err != nil {
+			return fmt.Errorf("at key %q: %w", keyText, err)
+		}
+		parsed.SetMapIndex(key, value)
+	}
+	rv.Set(parsed)
+	return nil
+}
+
+// Cuts the entry on the first KeyValueDelimiter, outside of quotes and escapes when quoted.
+func cutKeyValue(entry string, quoted bool) (key string, value string, found bool) {
+	if !quoted {
+		return strings.Cut(entry, KeyValueDelimiter)
+	}
+	delim, _ := utf8.DecodeRuneInString(KeyValueDelimiter)
+	pair, err := splitQuoted(entry, delim, 2)
+	if err != nil || len(pair) != 2 {
+		return entry, "", false
+	}
+	return pair[0], pair[1], true
+}
+
+// Formats a map as key value entries sorted by key.
+func (o EnvironOptions) formatMap(rv reflect.Value, state UnmarshalState) (string, error) {
+	entries := make([]string, 0, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		key, err := o.formatElement(iter.Key(), state)
+		if err != nil {
+			return "", fmt.Errorf("at key %v: %w", iter.Key(), err)
+		}
+		value, err := o.formatElement(iter.Value(), state)
+		if err != nil {
+			return "", fmt.Errorf("at key %v: %w", iter.Key(), err)
+		}
+		entries = append(entries, key+KeyValueDelimiter+value)
+	}
+	slices.Sort(entries)
+	return state.JoinElements(entries)
+}
+
+// Formats an element of a slice, array, or map, escaping it when quoted.
+func (o EnvironOptions) formatElement(rv reflect.Value, state UnmarshalState) (string, error) {
+	elementState := state
+	elementState.level++
+	text, _, err := o.formatText(rv, elementState, nil)
+	if err != nil {
+		return "", err
+	}
+	if state.Quoted() && !consumesLevel(rv.Type()) {
+		text = state.escape(text)
+	}
+	return text, nil
+}
@@ -0,0 +1,69 @@
+package env_test
+
+import (
+	"testing"
+
+	"github.com/clickermonkey/env"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestSplit struct {
+	Matrix  [][]int           `env:"MATRIX" env-delims:";,"`
+	Names   []string          `env:"NAMES" env-delims:","`
+	Pairs   []map[string]int  `env:"PAIRS" env-delims:";,"`
+	Labels  map[string]string `env:"LABELS"`
+	Grid    [2][2]string      `env:"GRID" env-delims:"|,"`
+	Default []string          `env:"DEFAULT" env-required:"false"`
+}
+
+func TestSplitElements(t *testing.T) {
+	actual, err := env.LoadWith[TestSplit](env.Loader{Source: env.MapSource{
+		"MATRIX": "1,2;3,4,5",
+		"NAMES":  `a,"b,c","say ""hi""",d\,e`,
+		"PAIRS":  `a=1,b=2;"c=d"=3`,
+		"LABELS": "team=core,tier=1",
+		"GRID":   `a,b|c,"d|e"`,
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, TestSplit{
+		Matrix: [][]int{{1, 2}, {3, 4, 5}},
+		Names:  []string{"a", "b,c", `say "hi"`, "d,e"},
+		Pairs:  []map[string]int{{"a": 1, "b": 2}, {"c=d": 3}},
+		Labels: map[string]string{"team": "core", "tier": "1"},
+		Grid:   [2][2]string{{"a", "b"}, {"c", "d|e"}},
+	}, actual)
+
+	vars, err := env.Marshal(actual)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"MATRIX": "1,2;3,4,5",
+		"NAMES":  `a,b\,c,say \"hi\",d\,e`,
+		"PAIRS":  `a=1,b=2;c\=d=3`,
+		"LABELS": "team=core,tier=1",
+		"GRID":   `a,b|c,d\|e`,
+	}, vars)
+
+	reloaded, err := env.LoadWith[TestSplit](env.Loader{Source: env.MapSource(vars)})
+	assert.NoError(t, err)
+	assert.Equal(t, actual, reloaded)
+}
+
+func TestSplitErrors(t *testing.T) {
+	_, err := env.LoadWith[TestSplit](env.Loader{Source: env.MapSource{
+		"MATRIX": "1",
+		"NAMES":  `a,"b`,
+		"PAIRS":  "a=1",
+		"LABELS": "a=1",
+		"GRID":   "a,b|c,d",
+	}})
+	assert.ErrorContains(t, err, `NAMES: error splitting: unterminated quote in "a,\"b"`)
+
+	_, err = env.LoadWith[TestSplit](env.Loader{Source: env.MapSource{
+		"MATRIX": "1",
+		"NAMES":  "a",
+		"PAIRS":  "a=1",
+		"LABELS": "nope",
+		"GRID":   "a,b|c,d",
+	}})
+	assert.ErrorContains(t, err, `LABELS: expected key=value but got "nope"`)
+}
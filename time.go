@@ -0,0 +1,136 @@
+package env
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// The struct tag which defines the layout of a time.Time value. This can be a
+	// time layout (ex: 2006-01-02), the name of a time package layout constant
+	// (ex: RFC1123, Kitchen, DateOnly), or unix, unixmilli, unixmicro, unixnano.
+	TagEnvLayout = "env-layout"
+
+	// The layout used for time.Time values without a TagEnvLayout struct tag.
+	DefaultTimeLayout = time.RFC3339Nano
+
+	// The value which parses as the current time.
+	TimeNow = "now"
+
+	// The named layouts which can be given in the TagEnvLayout struct tag, case-insensitive.
+	timeLayouts = map[string]string{
+		"ansic":       time.ANSIC,
+		"unixdate":    time.UnixDate,
+		"rubydate":    time.RubyDate,
+		"rfc822":      time.RFC822,
+		"rfc822z":     time.RFC822Z,
+		"rfc850":      time.RFC850,
+		"rfc1123":     time.RFC1123,
+		"rfc1123z":    time.RFC1123Z,
+		"rfc3339":     time.RFC3339,
+		"rfc3339nano": time.RFC3339Nano,
+		"kitchen":     time.Kitchen,
+		"stamp":       time.Stamp,
+		"stampmilli":  time.StampMilli,
+		"stampmicro":  time.StampMicro,
+		"stampnano":   time.StampNano,
+		"datetime":    time.DateTime,
+		"dateonly":    time.DateOnly,
+		"timeonly":    time.TimeOnly,
+	}
+
+	// The conversions from an integer for the unix layouts.
+	unixLayouts = map[string]func(int64) time.Time{
+		"unix":      func(count int64) time.Time { return time.Unix(count, 0) },
+		"unixmilli": time.UnixMilli,
+		"unixmicro": time.UnixMicro,
+		"unixnano":  func(count int64) time.Time { return time.Unix(0, count) },
+	}
+)
+
+// Registers the parsers and formatters for time.Time & *time.Location.
+func registerTime() {
+	RegisterParser[time.Time](func(state UnmarshalState) (any, error) {
+		value, exists := state.Read()
+		if !exists {
+			return nil, ErrMissing
+		}
+		return state.ParseTime(value)
+	})
+	RegisterFormatter[time.Time](func(value any, state UnmarshalState) (string, error) {
+		return state.FormatTime(value.(time.Time)), nil
+	})
+
+	RegisterParser[*time.Location](func(state UnmarshalState) (any, error) {
+		value, exists := state.Read()
+		if !exists {
+			return nil, ErrMissing
+		}
+		location, err := time.LoadLocation(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone %q, expected an IANA name like America/New_York", value)
+		}
+		return location, nil
+	})
+	RegisterFormatter[*time.Location](func(value any, state UnmarshalState) (string, error) {
+		return value.(*time.Location).String(), nil
+	})
+}
+
+// Returns the time layout from the TagEnvLayout struct tag or DefaultTimeLayout.
+// Named layouts are resolved to their time layout, unix layouts are returned lowercased.
+func (us UnmarshalState) Layout() string {
+	layout, _ := us.Tag(TagEnvLayout, DefaultTimeLayout)
+	named := strings.ToLower(layout)
+	if _, isUnix := unixLayouts[named]; isUnix {
+		return named
+	}
+	if resolved, exists := timeLayouts[named]; exists {
+		return resolved
+	}
+	return layout
+}
+
+// Parses the time with the layout of this state. Now is the current time
+// and a signed duration (ex: -24h) is relative to the current time.
+func (us UnmarshalState) ParseTime(value string) (time.Time, error) {
+	if value == TimeNow {
+		return time.Now(), nil
+	}
+	if strings.HasPrefix(value, "-") || strings.HasPrefix(value, "+") {
+		if relative, err := time.ParseDuration(value); err == nil {
+			return time.Now().Add(relative), nil
+		}
+	}
+	layout := us.Layout()
+	if fromUnix, isUnix := unixLayouts[layout]; isUnix {
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid %s time %q, expected an integer", layout, value)
+		}
+		return fromUnix(count).UTC(), nil
+	}
+	parsed, err := time.Parse(layout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected layout %s", value, layout)
+	}
+	return parsed, nil
+}
+
+// Formats the time with the layout of this state.
+func (us UnmarshalState) FormatTime(value time.Time) string {
+	layout := us.Layout()
+	switch layout {
+	case "unix":
+		return strconv.FormatInt(value.Unix(), 10)
+	case "unixmilli":
+		return strconv.FormatInt(value.UnixMilli(), 10)
+	case "unixmicro":
+		return strconv.FormatInt(value.UnixMicro(), 10)
+	case "unixnano":
+		return strconv.FormatInt(value.UnixNano(), 10)
+	}
+	return value.Format(layout)
+}
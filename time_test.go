@@ -0,0 +1,68 @@
+package env_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/clickermonkey/env"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestTime struct {
+	Default   time.Time      `env:"DEFAULT"`
+	Date      time.Time      `env:"DATE" env-layout:"2006-01-02"`
+	Named     time.Time      `env:"NAMED" env-layout:"kitchen"`
+	Unix      time.Time      `env:"UNIX" env-layout:"unix"`
+	UnixMilli *time.Time     `env:"UNIX_MILLI" env-layout:"unixmilli"`
+	Now       time.Time      `env:"NOW" env-default:"now"`
+	Yesterday time.Time      `env:"YESTERDAY" env-default:"-24h"`
+	Location  *time.Location `env:"LOCATION"`
+}
+
+func TestTimes(t *testing.T) {
+	before := time.Now()
+	actual, err := env.LoadWith[TestTime](env.Loader{Source: env.MapSource{
+		"DEFAULT":    "2024-03-04T05:06:07Z",
+		"DATE":       "2024-03-04",
+		"NAMED":      "3:04PM",
+		"UNIX":       "1700000000",
+		"UNIX_MILLI": "1700000000123",
+		"LOCATION":   "America/New_York",
+	}})
+	assert.NoError(t, err)
+
+	assert.Equal(t, time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC), actual.Default)
+	assert.Equal(t, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), actual.Date)
+	assert.Equal(t, time.Date(0, 1, 1, 15, 4, 0, 0, time.UTC), actual.Named)
+	assert.Equal(t, time.Unix(1700000000, 0).UTC(), actual.Unix)
+	assert.Equal(t, time.UnixMilli(1700000000123).UTC(), *actual.UnixMilli)
+	assert.WithinDuration(t, before, actual.Now, time.Minute)
+	assert.WithinDuration(t, before.Add(-24*time.Hour), actual.Yesterday, time.Minute)
+	assert.Equal(t, "America/New_York", actual.Location.String())
+
+	vars, err := env.Marshal(actual)
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-03-04T05:06:07Z", vars["DEFAULT"])
+	assert.Equal(t, "2024-03-04", vars["DATE"])
+	assert.Equal(t, "3:04PM", vars["NAMED"])
+	assert.Equal(t, "1700000000", vars["UNIX"])
+	assert.Equal(t, "1700000000123", vars["UNIX_MILLI"])
+	assert.Equal(t, "America/New_York", vars["LOCATION"])
+}
+
+func TestTimesErrors(t *testing.T) {
+	_, err := env.LoadWith[TestTime](env.Loader{Source: env.MapSource{
+		"DEFAULT": "2024-03-04T05:06:07Z",
+		"DATE":    "03/04/2024",
+	}})
+	assert.EqualError(t, err, `DATE: error in custom parser for type time.Time: invalid time "03/04/2024", expected layout 2006-01-02`)
+
+	_, err = env.LoadWith[TestTime](env.Loader{Source: env.MapSource{
+		"DEFAULT":  "2024-03-04T05:06:07Z",
+		"DATE":     "2024-03-04",
+		"NAMED":    "3:04PM",
+		"UNIX":     "1700000000",
+		"LOCATION": "Mars/Olympus",
+	}})
+	assert.EqualError(t, err, `LOCATION: error in custom parser for type *time.Location: invalid time zone "Mars/Olympus", expected an IANA name like America/New_York`)
+}
@@ -0,0 +1,122 @@
+package env
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Registers the parsers and formatters for standard library types which don't
+// implement encoding.TextUnmarshaler & encoding.TextMarshaler. Types such as
+// net.IP, netip.Addr, netip.AddrPort, netip.Prefix, and regexp.Regexp use those.
+func registerBuiltins() {
+	RegisterParser[url.URL](func(state UnmarshalState) (any, error) {
+		value, exists := state.Read()
+		if !exists {
+			return nil, ErrMissing
+		}
+		parsed, err := url.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid URL %q: %w", value, unwrapURLError(err))
+		}
+		return *parsed, nil
+	})
+	RegisterFormatter[url.URL](func(value any, state UnmarshalState) (string, error) {
+		parsed := value.(url.URL)
+		return parsed.String(), nil
+	})
+
+	RegisterParser[net.IPNet](func(state UnmarshalState) (any, error) {
+		value, exists := state.Read()
+		if !exists {
+			return nil, ErrMissing
+		}
+		_, parsed, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q, expected an address and prefix length like 10.0.0.0/8", value)
+		}
+		return *parsed, nil
+	})
+	RegisterFormatter[net.IPNet](func(value any, state UnmarshalState) (string, error) {
+		parsed := value.(net.IPNet)
+		return parsed.String(), nil
+	})
+
+	RegisterParser[mail.Address](func(state UnmarshalState) (any, error) {
+		value, exists := state.Read()
+		if !exists {
+			return nil, ErrMissing
+		}
+		parsed, err := mail.ParseAddress(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid email address %q: %w", value, err)
+		}
+		return *parsed, nil
+	})
+	RegisterFormatter[mail.Address](func(value any, state UnmarshalState) (string, error) {
+		parsed := value.(mail.Address)
+		return parsed.String(), nil
+	})
+
+	RegisterParser[os.FileMode](func(state UnmarshalState) (any, error) {
+		value, exists := state.Read()
+		if !exists {
+			return nil, ErrMissing
+		}
+		return ParseFileMode(value)
+	})
+	RegisterFormatter[os.FileMode](func(value any, state UnmarshalState) (string, error) {
+		return FormatFileMode(value.(os.FileMode)), nil
+	})
+
+	RegisterParser[*template.Template](func(state UnmarshalState) (any, error) {
+		value, exists := state.Read()
+		if !exists {
+			return nil, ErrMissing
+		}
+		parsed, err := template.New(state.String()).Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template: %w", err)
+		}
+		return parsed, nil
+	})
+	RegisterFormatter[*template.Template](func(value any, state UnmarshalState) (string, error) {
+		parsed := value.(*template.Template)
+		if parsed.Tree == nil || parsed.Tree.Root == nil {
+			return "", nil
+		}
+		return parsed.Tree.Root.String(), nil
+	})
+}
+
+// Parses a file mode from an octal string like 0755 or 755. A 0o, 0x, or 0b
+// prefix changes the base.
+func ParseFileMode(s string) (os.FileMode, error) {
+	base := 8
+	if len(s) > 1 && s[0] == '0' && strings.ContainsRune("oOxXbB", rune(s[1])) {
+		base = 0
+	}
+	parsed, err := strconv.ParseUint(s, base, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid file mode %q, expected an octal number like 0755", s)
+	}
+	return os.FileMode(parsed), nil
+}
+
+// Formats a file mode as an octal string like 0755.
+func FormatFileMode(mode os.FileMode) string {
+	return "0" + strconv.FormatUint(uint64(mode), 8)
+}
+
+// Returns the underlying error of a *url.Error, which repeats the value.
+func unwrapURLError(err error) error {
+	if urlError, ok := err.(*url.Error); ok {
+		return urlError.Err
+	}
+	return err
+}
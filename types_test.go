@@ -0,0 +1,127 @@
+package env_test
+
+import (
+	"net"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/clickermonkey/env"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestBuiltins struct {
+	URL      *url.URL           `env:"URL"`
+	URLValue url.URL            `env:"URL_VALUE"`
+	Addr     netip.Addr         `env:"ADDR"`
+	AddrPort netip.AddrPort     `env:"ADDR_PORT"`
+	Prefix   netip.Prefix       `env:"PREFIX"`
+	IP       net.IP             `env:"IP"`
+	IPNet    *net.IPNet         `env:"IP_NET"`
+	Regexp   *regexp.Regexp     `env:"REGEXP"`
+	Mail     mail.Address       `env:"MAIL"`
+	Template *template.Template `env:"TEMPLATE"`
+	IPs      []netip.Addr       `env:"IPS"`
+}
+
+var testBuiltinsVars = map[string]string{
+	"URL":       "https://example.com:8080/path?q=1",
+	"URL_VALUE": "postgres://user@db/name",
+	"ADDR":      "10.0.0.1",
+	"ADDR_PORT": "[::1]:8080",
+	"PREFIX":    "192.168.0.0/16",
+	"IP":        "127.0.0.1",
+	"IP_NET":    "10.0.0.0/8",
+	"REGEXP":    "^a+b$",
+	"MAIL":      "Bob <bob@example.com>",
+	"TEMPLATE":  "hello {{.}}",
+	"IPS":       "1.1.1.1,8.8.8.8",
+}
+
+func TestBuiltinTypes(t *testing.T) {
+	actual, err := env.LoadWith[TestBuiltins](env.Loader{Source: env.MapSource(testBuiltinsVars)})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "example.com:8080", actual.URL.Host)
+	assert.Equal(t, "postgres", actual.URLValue.Scheme)
+	assert.Equal(t, netip.MustParseAddr("10.0.0.1"), actual.Addr)
+	assert.Equal(t, netip.MustParseAddrPort("[::1]:8080"), actual.AddrPort)
+	assert.Equal(t, netip.MustParsePrefix("192.168.0.0/16"), actual.Prefix)
+	assert.True(t, actual.IP.Equal(net.IPv4(127, 0, 0, 1)))
+	assert.Equal(t, "10.0.0.0/8", actual.IPNet.String())
+	assert.True(t, actual.Regexp.MatchString("aab"))
+	assert.Equal(t, mail.Address{Name: "Bob", Address: "bob@example.com"}, actual.Mail)
+	assert.Equal(t, []netip.Addr{netip.MustParseAddr("1.1.1.1"), netip.MustParseAddr("8.8.8.8")}, actual.IPs)
+
+	executed := strings.Builder{}
+	assert.NoError(t, actual.Template.Execute(&executed, "world"))
+	assert.Equal(t, "hello world", executed.String())
+
+	vars, err := env.Marshal(actual)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"URL":       "https://example.com:8080/path?q=1",
+		"URL_VALUE": "postgres://user@db/name",
+		"ADDR":      "10.0.0.1",
+		"ADDR_PORT": "[::1]:8080",
+		"PREFIX":    "192.168.0.0/16",
+		"IP":        "127.0.0.1",
+		"IP_NET":    "10.0.0.0/8",
+		"REGEXP":    "^a+b$",
+		"MAIL":      `"Bob" <bob@example.com>`,
+		"TEMPLATE":  "hello {{.}}",
+		"IPS":       "1.1.1.1,8.8.8.8",
+	}, vars)
+}
+
+func TestBuiltinTypesErrors(t *testing.T) {
+	cases := []struct {
+		name          string
+		set           map[string]string
+		expectedError string
+	}{
+		{
+			name:          "url",
+			set:           map[string]string{"URL": "://missing"},
+			expectedError: `URL: error in custom parser for type url.URL: invalid URL "://missing": missing protocol scheme`,
+		},
+		{
+			name:          "addr",
+			set:           map[string]string{"ADDR": "10.0.0"},
+			expectedError: `ADDR: ParseAddr("10.0.0"): IPv4 address too short`,
+		},
+		{
+			name:          "ip net",
+			set:           map[string]string{"IP_NET": "10.0.0.0"},
+			expectedError: `IP_NET: error in custom parser for type net.IPNet: invalid CIDR "10.0.0.0", expected an address and prefix length like 10.0.0.0/8`,
+		},
+		{
+			name:          "mail",
+			set:           map[string]string{"MAIL": "bob"},
+			expectedError: `MAIL: error in custom parser for type mail.Address: invalid email address "bob": mail: missing '@' or angle-addr`,
+		},
+		{
+			name:          "regexp",
+			set:           map[string]string{"REGEXP": "(a"},
+			expectedError: "REGEXP: error parsing regexp: missing closing ): `(a`",
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			vars := map[string]string{}
+			for k, v := range testBuiltinsVars {
+				vars[k] = v
+			}
+			for k, v := range testCase.set {
+				vars[k] = v
+			}
+			_, err := env.LoadWith[TestBuiltins](env.Loader{Source: env.MapSource(vars)})
+			assert.EqualError(t, err, testCase.expectedError)
+		})
+	}
+}
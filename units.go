@@ -0,0 +1,235 @@
+package env
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// The struct tag which defines the unit of an integer value, ex: `env-unit:"bytes"`.
+	TagEnvUnit = "env-unit"
+
+	// The byte size units by lowercase suffix. SI units are powers of 1000,
+	// IEC units are powers of 1024, and the trailing B is optional.
+	byteUnits = map[string]uint64{
+		"":   1,
+		"b":  1,
+		"k":  1e3,
+		"kb": 1e3,
+		"m":  1e6,
+		"mb": 1e6,
+		"g":  1e9,
+		"gb": 1e9,
+		"t":  1e12,
+		"tb": 1e12,
+		"p":  1e15,
+		"pb": 1e15,
+		"e":  1e18,
+		"eb": 1e18,
+		"ki": 1 << 10, "kib": 1 << 10,
+		"mi": 1 << 20, "mib": 1 << 20,
+		"gi": 1 << 30, "gib": 1 << 30,
+		"ti": 1 << 40, "tib": 1 << 40,
+		"pi": 1 << 50, "pib": 1 << 50,
+		"ei": 1 << 60, "eib": 1 << 60,
+	}
+
+	// The byte size units used when formatting, largest first.
+	byteFormatUnits = []struct {
+		suffix string
+		size   uint64
+	}{
+		{"EiB", 1 << 60}, {"EB", 1e18},
+		{"PiB", 1 << 50}, {"PB", 1e15},
+		{"TiB", 1 << 40}, {"TB", 1e12},
+		{"GiB", 1 << 30}, {"GB", 1e9},
+		{"MiB", 1 << 20}, {"MB", 1e6},
+		{"KiB", 1 << 10}, {"kB", 1e3},
+	}
+
+	// The extended duration units, which are not supported by time.ParseDuration.
+	durationUnits = map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+	}
+
+	// The duration units used when formatting, largest first.
+	durationFormatUnits = []struct {
+		suffix string
+		size   time.Duration
+	}{
+		{"w", 7 * 24 * time.Hour},
+		{"d", 24 * time.Hour},
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+		{"ms", time.Millisecond},
+		{"us", time.Microsecond},
+		{"ns", time.Nanosecond},
+	}
+
+	byteSizePattern        = regexp.MustCompile(`^\s*(\d+(?:\.\d*)?|\.\d+)\s*([a-zA-Z]*)\s*$`)
+	durationSegmentPattern = regexp.MustCompile(`^(\d+(?:\.\d*)?|\.\d+)(ns|us|µs|μs|ms|s|m|h|d|w)`)
+)
+
+// The unit of an integer which is a number of bytes, ex: 10MB, 512KiB, 1.5G.
+const UnitBytes = "bytes"
+
+// Returns the unit in the TagEnvUnit struct tag, if any.
+func (us UnmarshalState) Unit() (string, error) {
+	unit, _ := us.Tag(TagEnvUnit, "")
+	switch unit {
+	case "", UnitBytes:
+		return unit, nil
+	}
+	return "", fmt.Errorf("unknown %s %q", TagEnvUnit, unit)
+}
+
+// Parses a byte size like 10MB, 512KiB, or 1.5G. SI units (kB, MB, GB, ...)
+// are powers of 1000 and IEC units (KiB, MiB, GiB, ...) are powers of 1024.
+// The unit is case-insensitive and the trailing B is optional.
+func ParseBytes(s string) (uint64, error) {
+	match := byteSizePattern.FindStringSubmatch(s)
+	if match == nil {
+		return 0, fmt.Errorf("invalid byte size %q, expected a number and unit like 10MB or 512KiB", s)
+	}
+	number, suffix := match[1], match[2]
+	unit, exists := byteUnits[strings.ToLower(suffix)]
+	if !exists {
+		return 0, fmt.Errorf("invalid byte size %q, unknown unit %q", s, suffix)
+	}
+	rangeErr := fmt.Errorf("invalid byte size %q: %w", s, strconv.ErrRange)
+	if !strings.Contains(number, ".") {
+		count, err := strconv.ParseUint(number, 10, 64)
+		if err != nil {
+			return 0, rangeErr
+		}
+		high, size := bits.Mul64(count, unit)
+		if high != 0 {
+			return 0, rangeErr
+		}
+		return size, nil
+	}
+	count, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte size %q: %w", s, err)
+	}
+	size := math.Round(count * float64(unit))
+	if size >= math.MaxUint64 {
+		return 0, rangeErr
+	}
+	return uint64(size), nil
+}
+
+// Formats a byte size with the largest unit that represents it exactly.
+func FormatBytes(size uint64) string {
+	for _, unit := range byteFormatUnits {
+		if size >= unit.size && size%unit.size == 0 {
+			return strconv.FormatUint(size/unit.size, 10) + unit.suffix
+		}
+	}
+	return strconv.FormatUint(size, 10) + "B"
+}
+
+// A time.Duration which also accepts days (d) and weeks (w), ex: 7d, 2w, 1d12h.
+type Duration time.Duration
+
+// Returns the time.Duration.
+func (d Duration) Duration() time.Duration {
+	return time.Duration(d)
+}
+
+func (d Duration) String() string {
+	return FormatDuration(time.Duration(d))
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Parses a duration like time.ParseDuration which also accepts days (d) and weeks (w).
+func ParseDuration(s string) (time.Duration, error) {
+	remaining := s
+	negative := false
+	if remaining != "" && (remaining[0] == '-' || remaining[0] == '+') {
+		negative = remaining[0] == '-'
+		remaining = remaining[1:]
+	}
+	if remaining == "0" {
+		return 0, nil
+	}
+	if remaining == "" {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	var total time.Duration
+	for remaining != "" {
+		match := durationSegmentPattern.FindStringSubmatch(remaining)
+		if match == nil {
+			return 0, fmt.Errorf("invalid duration %q, expected numbers and units like 1w2d3h4m5s", s)
+		}
+		segment, number, unit := match[0], match[1], match[2]
+		remaining = remaining[len(segment):]
+
+		var parsed time.Duration
+		if size, extended := durationUnits[unit]; extended {
+			count, err := strconv.ParseFloat(number, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+			}
+			if count*float64(size) > math.MaxInt64 {
+				return 0, fmt.Errorf("invalid duration %q: out of range", s)
+			}
+			parsed = time.Duration(count * float64(size))
+		} else {
+			var err error
+			parsed, err = time.ParseDuration(segment)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q: out of range", s)
+			}
+		}
+		if total > math.MaxInt64-parsed {
+			return 0, fmt.Errorf("invalid duration %q: out of range", s)
+		}
+		total += parsed
+	}
+	if negative {
+		total = -total
+	}
+	return total, nil
+}
+
+// Formats a duration with weeks and days, ex: 1w2d3h4m5s. Zero units are left out.
+func FormatDuration(d time.Duration) string {
+	if d == 0 {
+		return "0s"
+	}
+	var formatted strings.Builder
+	// math.MinInt64 can't be negated, the remainder is accumulated as negative instead.
+	if d < 0 {
+		formatted.WriteString("-")
+	} else {
+		d = -d
+	}
+	for _, unit := range durationFormatUnits {
+		if count := d / unit.size; count != 0 {
+			formatted.WriteString(strconv.FormatInt(-int64(count), 10))
+			formatted.WriteString(unit.suffix)
+			d -= count * unit.size
+		}
+	}
+	return formatted.String()
+}
@@ -0,0 +1,118 @@
+package env_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/clickermonkey/env"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestUnitsConfig struct {
+	Buffer  int          `env:"BUFFER" env-unit:"bytes"`
+	Limit   uint64       `env:"LIMIT" env-unit:"bytes"`
+	Small   uint16       `env:"SMALL" env-unit:"bytes" env-default:"1KiB"`
+	Sizes   []uint32     `env:"SIZES" env-unit:"bytes" env-required:"false"`
+	Timeout env.Duration `env:"TIMEOUT"`
+	Retry   env.Duration `env:"RETRY" env-default:"1d12h"`
+}
+
+func TestUnits(t *testing.T) {
+	actual, err := env.LoadWith[TestUnitsConfig](env.Loader{Source: env.MapSource{
+		"BUFFER":  "10MB",
+		"LIMIT":   "1.5g",
+		"SIZES":   "512KiB,2k,7",
+		"TIMEOUT": "2w",
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, TestUnitsConfig{
+		Buffer:  10_000_000,
+		Limit:   1_500_000_000,
+		Small:   1024,
+		Sizes:   []uint32{512 * 1024, 2000, 7},
+		Timeout: env.Duration(14 * 24 * time.Hour),
+		Retry:   env.Duration(36 * time.Hour),
+	}, actual)
+
+	vars, err := env.Marshal(actual)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"BUFFER":  "10MB",
+		"LIMIT":   "1500MB",
+		"SMALL":   "1KiB",
+		"SIZES":   "512KiB,2kB,7B",
+		"TIMEOUT": "2w",
+		"RETRY":   "1d12h",
+	}, vars)
+}
+
+func TestUnitsErrors(t *testing.T) {
+	_, err := env.LoadWith[TestUnitsConfig](env.Loader{Source: env.MapSource{
+		"BUFFER":  "10MB",
+		"LIMIT":   "1.5g",
+		"SMALL":   "64KiB",
+		"TIMEOUT": "2w",
+	}})
+	assert.EqualError(t, err, `SMALL: "64KiB" overflows 16-bit unsigned integer: value out of range`)
+
+	_, err = env.LoadWith[TestUnitsConfig](env.Loader{Source: env.MapSource{
+		"BUFFER": "10 parsecs",
+	}})
+	assert.EqualError(t, err, `BUFFER: invalid byte size "10 parsecs", unknown unit "parsecs"`)
+
+	_, err = env.LoadWith[TestUnitsConfig](env.Loader{Source: env.MapSource{
+		"BUFFER":  "10MB",
+		"LIMIT":   "1.5g",
+		"TIMEOUT": "2 weeks",
+	}})
+	assert.EqualError(t, err, `TIMEOUT: invalid duration "2 weeks", expected numbers and units like 1w2d3h4m5s`)
+}
+
+func TestParseDuration(t *testing.T) {
+	cases := map[string]time.Duration{
+		"0":          0,
+		"1.5h":       90 * time.Minute,
+		"7d":         7 * 24 * time.Hour,
+		"-1w2d":      -9 * 24 * time.Hour,
+		"1w2d3h4m5s": 9*24*time.Hour + 3*time.Hour + 4*time.Minute + 5*time.Second,
+		"1.5d":       36 * time.Hour,
+		"250ms":      250 * time.Millisecond,
+		"1h30m500us": 90*time.Minute + 500*time.Microsecond,
+	}
+	for text, expected := range cases {
+		actual, err := env.ParseDuration(text)
+		assert.NoError(t, err, text)
+		assert.Equal(t, expected, actual, text)
+	}
+
+	assert.Equal(t, "1w2d3h4m5s", env.FormatDuration(9*24*time.Hour+3*time.Hour+4*time.Minute+5*time.Second))
+	assert.Equal(t, "-1d500ms", env.FormatDuration(-24*time.Hour-500*time.Millisecond))
+	assert.Equal(t, "0s", env.FormatDuration(0))
+}
+
+func TestParseBytes(t *testing.T) {
+	cases := map[string]uint64{
+		"0":      0,
+		"1":      1,
+		"1B":     1,
+		"1kb":    1000,
+		"1KiB":   1024,
+		"1.5GiB": 1536 << 20,
+		"2 TB":   2e12,
+		".5M":    500_000,
+	}
+	for text, expected := range cases {
+		actual, err := env.ParseBytes(text)
+		assert.NoError(t, err, text)
+		assert.Equal(t, expected, actual, text)
+	}
+
+	_, err := env.ParseBytes("20EiB")
+	assert.EqualError(t, err, `invalid byte size "20EiB": value out of range`)
+	assert.ErrorIs(t, err, strconv.ErrRange)
+
+	assert.Equal(t, "1536MiB", env.FormatBytes(1536<<20))
+	assert.Equal(t, "1001B", env.FormatBytes(1001))
+	assert.Equal(t, "0B", env.FormatBytes(0))
+}
@@ -0,0 +1,104 @@
+package env_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/clickermonkey/env"
+	"github.com/stretchr/testify/assert"
+)
+
+// A URL which must be https, decoded with encoding.TextUnmarshaler.
+type TestSecureURL struct {
+	url.URL
+}
+
+func (u *TestSecureURL) UnmarshalText(text []byte) error {
+	parsed, err := url.Parse(string(text))
+	if err != nil {
+		return err
+	}
+	u.URL = *parsed
+	return nil
+}
+
+func (u TestSecureURL) ValidateEnv(state env.UnmarshalState) error {
+	if u.Scheme != "https" {
+		return errors.New("expected https")
+	}
+	return nil
+}
+
+// A port decoded with env.Unmarshaller.
+type TestPort int
+
+func (p *TestPort) UnmarshalEnv(state env.UnmarshalState) error {
+	value, exists := state.Read()
+	if !exists {
+		return env.ErrMissing
+	}
+	*p = TestPort(len(value))
+	return nil
+}
+
+func (p *TestPort) ValidateEnv(state env.UnmarshalState) error {
+	if *p > 3 {
+		return errors.New("port too long")
+	}
+	return nil
+}
+
+// A name decoded with a registered parser.
+type TestParsedName string
+
+func init() {
+	env.RegisterParser[TestParsedName](func(state env.UnmarshalState) (any, error) {
+		value, _ := state.Read()
+		return TestParsedName(value), nil
+	})
+}
+
+func (n TestParsedName) ValidateEnv(state env.UnmarshalState) error {
+	if n == "" {
+		return errors.New("empty name")
+	}
+	return nil
+}
+
+type TestValidate struct {
+	URL     TestSecureURL    `env:"URL"`
+	Port    *TestPort        `env:"PORT"`
+	Name    TestParsedName   `env:"NAME"`
+	Mirrors []TestSecureURL  `env:"MIRRORS" env-required:"false"`
+	Aliases []TestParsedName `env:"ALIASES" env-required:"false"`
+}
+
+func TestValidateDecoders(t *testing.T) {
+	vars := map[string]string{
+		"URL":     "https://example.com",
+		"PORT":    "443",
+		"NAME":    "n",
+		"MIRRORS": "https://a.example.com,https://b.example.com",
+		"ALIASES": "a,b",
+	}
+	actual, err := env.LoadWith[TestValidate](env.Loader{Source: env.MapSource(vars)})
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com", actual.URL.Host)
+	assert.Len(t, actual.Mirrors, 2)
+
+	invalid := func(name, value string) error {
+		changed := map[string]string{}
+		for k, v := range vars {
+			changed[k] = v
+		}
+		changed[name] = value
+		_, err := env.LoadWith[TestValidate](env.Loader{Source: env.MapSource(changed)})
+		return err
+	}
+	assert.EqualError(t, invalid("URL", "http://example.com"), "URL: expected https")
+	assert.EqualError(t, invalid("PORT", "8080"), "PORT: port too long")
+	assert.EqualError(t, invalid("NAME", ""), "NAME: empty name")
+	assert.EqualError(t, invalid("MIRRORS", "https://a.example.com,http://b.example.com"), "MIRRORS: at index 1: expected https")
+	assert.EqualError(t, invalid("ALIASES", "a,,b"), "ALIASES: at index 1: empty name")
+}